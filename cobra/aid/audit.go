@@ -0,0 +1,276 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// RedactedValue replaces secrets in audit entries and logs
+const RedactedValue = "[REDACTED]"
+
+// SensitiveFlags lists the flags whose values must never be written to the audit journal
+var SensitiveFlags = []string{
+	"value",
+	"user-token",
+	"team-token",
+	"organization-token",
+	"o-auth-token",
+	"private-key",
+	"private-ssh-key",
+	"token",
+	"var",
+	"url",
+}
+
+// mutatingVerbs are the words that mark a command argument as a change, e.g. delete-all or force-unlock
+var mutatingVerbs = []string{
+	"create",
+	"update",
+	"delete",
+	"apply",
+	"cancel",
+	"discard",
+	"lock",
+	"unlock",
+	"assign",
+	"unassign",
+	"remove",
+	"upload",
+	"override",
+	"push",
+	"add",
+	"migrate",
+	"set",
+}
+
+// SetAuditFlags define flags for the cobra command
+func SetAuditFlags(cmd *cobra.Command) {
+	usage := `Only show entries newer than the given duration (e.g. 24h) or RFC3339 timestamp.`
+	cmd.Flags().String("since", "", usage)
+
+	usage = `Only show entries that targeted the given resource ID or name.`
+	cmd.Flags().String("resource", "", usage)
+}
+
+// GetAuditSince return the lower time bound given by the --since flag
+func GetAuditSince(cmd *cobra.Command) (time.Time, error) {
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to get flag since\n%w", err)
+	}
+
+	return ParseSince(since, time.Now())
+}
+
+// ParseSince converts a duration (relative to now) or a RFC3339 timestamp into a time
+func ParseSince(since string, now time.Time) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid value %q, expected a duration (e.g. 24h) or a RFC3339 timestamp", since)
+	}
+
+	return t, nil
+}
+
+// IsMutatingArgument returns true if the given command argument changes a resource
+func IsMutatingArgument(argument string) bool {
+	for _, word := range strings.Split(argument, "-") {
+		if helper.ContainsString(mutatingVerbs, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetAuditResources return the identifiers given to the command, e.g. workspace-id=ws-XXXXXXXX
+func GetAuditResources(cmd *cobra.Command) []string {
+	var resources []string
+	for _, flag := range []string{"id", "name", "workspace-id", "configuration-version-id", "ssh-key-id", "key", "username", "team", "team-id", "workspace", "source", "policy", "project"} {
+		if cmd.Flags().Lookup(flag) == nil || !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		resources = append(resources, flag+"="+cmd.Flags().Lookup(flag).Value.String())
+	}
+
+	return resources
+}
+
+// RedactArgs returns a copy of the command line with the values of sensitive flags replaced
+func RedactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+
+	for i := 0; i < len(redacted); i++ {
+		name := strings.TrimLeft(redacted[i], "-")
+		if !strings.HasPrefix(redacted[i], "-") || name == "" {
+			continue
+		}
+
+		if flag, _, found := strings.Cut(name, "="); found {
+			if helper.ContainsString(SensitiveFlags, flag) {
+				redacted[i] = "--" + flag + "=" + RedactedValue
+			}
+			continue
+		}
+
+		if helper.ContainsString(SensitiveFlags, name) && i+1 < len(redacted) {
+			redacted[i+1] = RedactedValue
+			i++
+		}
+	}
+
+	return redacted
+}
+
+// GetOSUsername returns the name of the user running tecli
+func GetOSUsername() string {
+	u, err := user.Current()
+	if err != nil {
+		return "unknown"
+	}
+
+	return u.Username
+}
+
+// WriteAuditEntry appends the given entry to the local audit journal and forwards it to the sink, if any
+func WriteAuditEntry(entry model.AuditEntry, sink string) error {
+	app := GetAppInfo()
+	helper.MkDirsIfNotExist(app.ConfigurationsDir)
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("unable to encode audit entry\n%w", err)
+	}
+
+	if err := appendLine(app.AuditFilePath, b); err != nil {
+		return fmt.Errorf("unable to write audit journal\n%w", err)
+	}
+
+	if err := forwardAuditEntry(sink, b); err != nil {
+		// the local journal is the source of truth, a failing sink must not fail the command
+		logrus.Errorf("unable to forward audit entry to %s\n%v", sink, err)
+	}
+
+	return nil
+}
+
+// ReadAuditEntries return the journal entries newer than since that targeted the given resource
+func ReadAuditEntries(since time.Time, resource string) ([]model.AuditEntry, error) {
+	var entries []model.AuditEntry
+
+	file, err := os.Open(GetAppInfo().AuditFilePath)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return entries, fmt.Errorf("unable to open audit journal\n%w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry model.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			logrus.Warnf("skipping malformed audit entry\n%v", err)
+			continue
+		}
+
+		if entry.Timestamp.Before(since) {
+			continue
+		}
+
+		if resource != "" && !auditEntryTargets(entry, resource) {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("unable to read audit journal\n%w", err)
+	}
+
+	return entries, nil
+}
+
+func auditEntryTargets(entry model.AuditEntry, resource string) bool {
+	for _, r := range entry.Resources {
+		_, value, found := strings.Cut(r, "=")
+		if r == resource || (found && value == resource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func forwardAuditEntry(sink string, b []byte) error {
+	switch {
+	case sink == "":
+		return nil
+	case sink == "syslog":
+		return writeSyslog(string(b))
+	case strings.HasPrefix(sink, "file:"):
+		return appendLine(strings.TrimPrefix(sink, "file:"), b)
+	default:
+		return fmt.Errorf("unknown audit sink %q, valid values are: syslog or file:<path>", sink)
+	}
+}
+
+func appendLine(path string, b []byte) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(b, '\n'))
+	return err
+}
+
+// PrintAuditList prints the audit entries as JSON
+func PrintAuditList(entries []model.AuditEntry) {
+	for i, entry := range entries {
+		if i < len(entries)-1 {
+			fmt.Printf("%v,\n", ToJSON(entry))
+		} else {
+			fmt.Printf("%v\n", ToJSON(entry))
+		}
+	}
+}
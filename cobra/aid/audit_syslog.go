@@ -0,0 +1,30 @@
+//go:build !windows && !plan9
+
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import "log/syslog"
+
+func writeSyslog(message string) error {
+	writer, err := syslog.New(syslog.LOG_NOTICE|syslog.LOG_USER, "tecli")
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	return writer.Notice(message)
+}
@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	tfe "github.com/hashicorp/go-tfe"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -53,24 +52,24 @@ func SetConfigurationVersionFlags(cmd *cobra.Command) {
 }
 
 // GetConfigurationVersionCreateOptions return options based on the flags values
-func GetConfigurationVersionCreateOptions(cmd *cobra.Command) tfe.ConfigurationVersionCreateOptions {
+func GetConfigurationVersionCreateOptions(cmd *cobra.Command) (tfe.ConfigurationVersionCreateOptions, error) {
 	var options tfe.ConfigurationVersionCreateOptions
 
 	autoQueueRuns, err := cmd.Flags().GetBool("auto-queue-runs")
 	if err != nil {
-		logrus.Fatalf("unable to get flag configuration-version-auto-queue-runs\n%v", err)
+		return options, fmt.Errorf("unable to get flag configuration-version-auto-queue-runs\n%w", err)
 	}
 
 	options.AutoQueueRuns = &autoQueueRuns
 
 	speculative, err := cmd.Flags().GetBool("speculative")
 	if err != nil {
-		logrus.Fatalf("unable to get flag configuration-version-speculative\n%v", err)
+		return options, fmt.Errorf("unable to get flag configuration-version-speculative\n%w", err)
 	}
 
 	options.Speculative = &speculative
 
-	return options
+	return options, nil
 }
 
 // PrintConfigurationVersionList TODO ...
@@ -0,0 +1,129 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// CostEstimateFormats are the output formats of cost-estimate
+var CostEstimateFormats = []string{"table", "json"}
+
+// SetCostEstimateFlags define flags for the cobra command
+func SetCostEstimateFlags(cmd *cobra.Command) {
+	usage := `The Cost Estimate ID`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `Used with read-for-run. The ID of the run whose cost estimate is read.`
+	cmd.Flags().String("run-id", "", usage)
+
+	usage = `Output format: table or json.`
+	cmd.Flags().String("format", "table", usage)
+}
+
+// FormatCost formats a monthly cost returned by the API, e.g. 25.5 as $25.50
+func FormatCost(cost string) string {
+	value, err := strconv.ParseFloat(cost, 64)
+	if err != nil {
+		return cost
+	}
+
+	return fmt.Sprintf("$%.2f", value)
+}
+
+// FormatCostDelta formats a monthly cost difference with its sign, e.g. -3.2 as -$3.20
+func FormatCostDelta(delta string) string {
+	value, err := strconv.ParseFloat(delta, 64)
+	if err != nil {
+		return delta
+	}
+
+	if value < 0 {
+		return fmt.Sprintf("-$%.2f", -value)
+	}
+
+	return fmt.Sprintf("+$%.2f", value)
+}
+
+// RenderCostEstimate renders the costs and the resources of a cost estimate
+func RenderCostEstimate(ce *tfe.CostEstimate) string {
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\t%s\n", ce.ID)
+	fmt.Fprintf(w, "STATUS\t%s\n", ce.Status)
+	if ce.Status == tfe.CostEstimateFinished {
+		fmt.Fprintf(w, "PRIOR MONTHLY COST\t%s\n", FormatCost(ce.PriorMonthlyCost))
+		fmt.Fprintf(w, "PROPOSED MONTHLY COST\t%s\n", FormatCost(ce.ProposedMonthlyCost))
+		fmt.Fprintf(w, "DELTA\t%s\n", FormatCostDelta(ce.DeltaMonthlyCost))
+		fmt.Fprintf(w, "RESOURCES\t%d (%d matched, %d unmatched)\n", ce.ResourcesCount, ce.MatchedResourcesCount, ce.UnmatchedResourcesCount)
+	}
+	if ce.ErrorMessage != "" {
+		fmt.Fprintf(w, "ERROR\t%s\n", ce.ErrorMessage)
+	}
+	w.Flush()
+
+	return out.String()
+}
+
+// RenderRunCostList renders the runs with the monthly cost of their cost estimate
+func RenderRunCostList(runs []*tfe.Run) string {
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RUN\tSTATUS\tCREATED\tPROPOSED\tDELTA\tMESSAGE")
+	for _, r := range runs {
+		proposed, delta := "-", "-"
+		if ce := r.CostEstimate; ce != nil && ce.Status == tfe.CostEstimateFinished {
+			proposed, delta = FormatCost(ce.ProposedMonthlyCost), FormatCostDelta(ce.DeltaMonthlyCost)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.Status, r.CreatedAt.Format("2006-01-02 15:04"), proposed, delta, truncateMessage(r.Message, 40))
+	}
+	w.Flush()
+
+	return out.String()
+}
+
+// CheckCostPreconditions returns the reasons why the cost estimate doesn't meet the preconditions
+func CheckCostPreconditions(preconditions ApplyPreconditions, ce *tfe.CostEstimate) []string {
+	if preconditions.MaxDelta == nil {
+		return nil
+	}
+
+	if ce == nil {
+		return []string{"the run has no cost estimate"}
+	}
+
+	if ce.Status != tfe.CostEstimateFinished {
+		return []string{fmt.Sprintf("the cost estimate is %s, not finished", ce.Status)}
+	}
+
+	delta, err := strconv.ParseFloat(ce.DeltaMonthlyCost, 64)
+	if err != nil {
+		return []string{fmt.Sprintf("the cost estimate has an invalid delta %q", ce.DeltaMonthlyCost)}
+	}
+
+	if delta > *preconditions.MaxDelta {
+		return []string{fmt.Sprintf("the monthly cost changes by %s, more than the maximum of %s", FormatCostDelta(ce.DeltaMonthlyCost), FormatCostDelta(strconv.FormatFloat(*preconditions.MaxDelta, 'f', 2, 64)))}
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// Exit codes returned by tecli. 2 means "changes present", like terraform plan
+// -detailed-exitcode.
+const (
+	ExitCodeOK           = 0
+	ExitCodeError        = 1
+	ExitCodeChanges      = 2
+	ExitCodeValidation   = 3
+	ExitCodeUnauthorized = 4
+	ExitCodeNotFound     = 5
+	ExitCodeConflict     = 6
+	ExitCodeRateLimited  = 7
+	ExitCodePrecondition = 8
+)
+
+// Kinds of errors, as reported by --error-format json
+const (
+	ErrorKindError        = "error"
+	ErrorKindValidation   = "validation"
+	ErrorKindUnauthorized = "unauthorized"
+	ErrorKindNotFound     = "not-found"
+	ErrorKindConflict     = "conflict"
+	ErrorKindRateLimited  = "rate-limited"
+	ErrorKindChanges      = "changes"
+	ErrorKindPrecondition = "precondition"
+)
+
+// Error is an error classified into a kind and an exit code
+type Error struct {
+	Code       int    `json:"code"`
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	Resource   string `json:"resource,omitempty"`
+	HTTPStatus int    `json:"httpStatus,omitempty"`
+
+	err error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the classified error
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+var conflictErrors = []error{
+	tfe.ErrWorkspaceLocked,
+	tfe.ErrWorkspaceNotLocked,
+	tfe.ErrWorkspaceLockedByRun,
+	tfe.ErrWorkspaceLockedByTeam,
+	tfe.ErrWorkspaceLockedByUser,
+	tfe.ErrWorkspaceLockedStateVersionStillPending,
+	tfe.ErrWorkspaceLockedCannotDelete,
+	tfe.ErrWorkspaceStillProcessing,
+	tfe.ErrWorkspaceNotSafeToDelete,
+}
+
+// messages of errors raised before reaching the API, by go-tfe or by the flag validation
+var validationPrefixes = []string{
+	"required",
+	"invalid",
+	"unsupported",
+	"unknown",
+	"--",
+	"this command",
+	"command requires",
+}
+
+var validationSuffixes = []string{
+	"is required",
+	"are required",
+	"must be defined",
+}
+
+// statusRegexp matches the messages go-tfe makes of the status line of the
+// error responses it can't decode, e.g. "429 Too Many Requests"
+var statusRegexp = regexp.MustCompile(`^([1-5][0-9]{2}) `)
+
+// statusTitles maps the titles of the JSON:API error payloads to their status
+var statusTitles = map[string]int{
+	"bad request":          http.StatusBadRequest,
+	"forbidden":            http.StatusForbidden,
+	"conflict":             http.StatusConflict,
+	"unprocessable entity": http.StatusUnprocessableEntity,
+	"too many requests":    http.StatusTooManyRequests,
+}
+
+// ClassifyError maps the given error to a kind, an exit code and, when known, an HTTP status
+func ClassifyError(err error) *Error {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified
+	}
+
+	classified = &Error{Code: ExitCodeError, Kind: ErrorKindError, Message: err.Error(), err: err}
+	status := responseStatus(err)
+
+	switch {
+	case errors.Is(err, tfe.ErrUnauthorized), errors.Is(err, tfe.ErrNamespaceNotAuthorized):
+		classified.setKind(ErrorKindUnauthorized, ExitCodeUnauthorized, http.StatusUnauthorized)
+	case errors.Is(err, tfe.ErrResourceNotFound):
+		classified.setKind(ErrorKindNotFound, ExitCodeNotFound, http.StatusNotFound)
+	case isConflictError(err):
+		classified.setKind(ErrorKindConflict, ExitCodeConflict, http.StatusConflict)
+	case errors.Is(err, tfe.ErrInvalidIncludeValue):
+		classified.setKind(ErrorKindValidation, ExitCodeValidation, http.StatusBadRequest)
+	case status != 0:
+		classified.classifyHTTPStatus(status)
+	case hasValidationMessage(err):
+		classified.setKind(ErrorKindValidation, ExitCodeValidation, 0)
+	}
+
+	return classified
+}
+
+func (e *Error) classifyHTTPStatus(status int) {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		e.setKind(ErrorKindValidation, ExitCodeValidation, status)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		e.setKind(ErrorKindUnauthorized, ExitCodeUnauthorized, status)
+	case http.StatusNotFound:
+		e.setKind(ErrorKindNotFound, ExitCodeNotFound, status)
+	case http.StatusConflict:
+		e.setKind(ErrorKindConflict, ExitCodeConflict, status)
+	case http.StatusTooManyRequests:
+		e.setKind(ErrorKindRateLimited, ExitCodeRateLimited, status)
+	default:
+		e.HTTPStatus = status
+	}
+}
+
+func (e *Error) setKind(kind string, code int, status int) {
+	e.Kind = kind
+	e.Code = code
+	e.HTTPStatus = status
+}
+
+func isConflictError(err error) bool {
+	for _, target := range conflictErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// responseStatus returns the status of the error response go-tfe turned into
+// the given error, or 0 when the message doesn't tell
+func responseStatus(err error) int {
+	message := strings.ToLower(innermostError(err).Error())
+	if match := statusRegexp.FindStringSubmatch(message); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		return status
+	}
+
+	for title, status := range statusTitles {
+		if message == title || strings.HasPrefix(message, title+"\n") {
+			return status
+		}
+	}
+
+	return 0
+}
+
+func hasValidationMessage(err error) bool {
+	message := strings.ToLower(innermostError(err).Error())
+	for _, prefix := range validationPrefixes {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+
+	for _, suffix := range validationSuffixes {
+		if strings.HasSuffix(message, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// innermostError returns the error at the bottom of the chain, as returned by go-tfe
+func innermostError(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
@@ -2,6 +2,7 @@ package aid
 
 import (
 	"encoding/json"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 )
@@ -15,3 +16,25 @@ func ToJSON(v interface{}) string {
 
 	return string(b)
 }
+
+// RunConcurrently calls fn for every index in [0, count) with at most concurrency calls at the same time
+func RunConcurrently(concurrency int, count int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,197 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/awslabs/tecli/helper"
+	"github.com/sirupsen/logrus"
+)
+
+// maxLoggedBodySize bounds how much of a payload is written to the logs
+const maxLoggedBodySize = 64 * 1024
+
+// SensitiveAttributes lists the JSON attributes whose values are never logged
+var SensitiveAttributes = []string{
+	"token",
+	"private-key",
+	"private-ssh-key",
+	"ssh-key",
+	"oauth-token-string",
+	"secret",
+	"hmac-key",
+	"url",
+}
+
+var debugHTTP bool
+
+// SetDebugHTTP enables the logging of every request and response made to the API
+func SetDebugHTTP(enabled bool) {
+	debugHTTP = enabled
+}
+
+// loggingRoundTripper dumps every request and response at debug level
+type loggingRoundTripper struct {
+	next http.RoundTripper
+}
+
+// NewLoggingRoundTripper wraps the given transport, logging every request and response
+func NewLoggingRoundTripper(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &loggingRoundTripper{next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (l *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read request body\n%w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	fields := logrus.Fields{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": RedactHeaders(req.Header),
+	}
+	if len(reqBody) > 0 {
+		fields["request"] = loggableBody(req.Header.Get("Content-Type"), reqBody)
+	}
+
+	start := time.Now()
+	resp, err := l.next.RoundTrip(req)
+	fields["latency"] = time.Since(start).String()
+
+	if err != nil {
+		logrus.WithFields(fields).WithError(err).Debug("http request failed")
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if resp.Body != nil {
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read response body\n%w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+		if len(respBody) > 0 {
+			fields["response"] = loggableBody(resp.Header.Get("Content-Type"), respBody)
+		}
+	}
+
+	logrus.WithFields(fields).Debug("http request")
+
+	return resp, nil
+}
+
+// RedactHeaders returns a copy of the headers without credentials
+func RedactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for _, name := range []string{"Authorization", "Cookie", "Set-Cookie"} {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, RedactedValue)
+		}
+	}
+
+	return redacted
+}
+
+// RedactJSON replaces the values of sensitive variables and attributes of a JSON payload
+func RedactJSON(b []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(redactJSONValue(v))
+}
+
+func redactJSONValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		sensitive, _ := value["sensitive"].(bool)
+		for k, item := range value {
+			if item == nil {
+				continue
+			}
+
+			if helper.ContainsString(SensitiveAttributes, k) || (sensitive && k == "value") {
+				value[k] = RedactedValue
+				continue
+			}
+
+			// run-scoped variables have no sensitive attribute, their values are always redacted
+			if variables, ok := item.([]interface{}); ok && k == "variables" {
+				value[k] = redactRunVariables(variables)
+				continue
+			}
+
+			value[k] = redactJSONValue(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactJSONValue(item)
+		}
+	}
+
+	return v
+}
+
+func redactRunVariables(variables []interface{}) []interface{} {
+	for _, variable := range variables {
+		if v, ok := variable.(map[string]interface{}); ok {
+			if _, found := v["value"]; found {
+				v["value"] = RedactedValue
+			}
+		}
+	}
+
+	return variables
+}
+
+func loggableBody(contentType string, b []byte) string {
+	if !strings.Contains(contentType, "json") {
+		return fmt.Sprintf("<%d bytes of %s>", len(b), contentType)
+	}
+
+	if len(b) > maxLoggedBodySize {
+		return fmt.Sprintf("<%d bytes of %s, too large to log>", len(b), contentType)
+	}
+
+	redacted, err := RedactJSON(b)
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of malformed %s>", len(b), contentType)
+	}
+
+	return string(redacted)
+}
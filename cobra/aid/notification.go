@@ -0,0 +1,380 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// NotificationSignatureHeader is the header holding the HMAC signature of a notification payload
+const NotificationSignatureHeader = "X-TFE-Notification-Signature"
+
+// MaxNotificationBodySize bounds the body read by the notification listener, the signature
+// can only be checked once the whole body is read
+const MaxNotificationBodySize = 1 << 20
+
+// NotificationDestinationTypes are the valid values of --destination-type
+var NotificationDestinationTypes = []string{"generic", "slack", "microsoft-teams", "email"}
+
+// NotificationTriggers are the valid values of --trigger
+var NotificationTriggers = []string{
+	string(tfe.NotificationTriggerCreated),
+	string(tfe.NotificationTriggerPlanning),
+	string(tfe.NotificationTriggerNeedsAttention),
+	string(tfe.NotificationTriggerApplying),
+	string(tfe.NotificationTriggerCompleted),
+	string(tfe.NotificationTriggerErrored),
+	string(tfe.NotificationTriggerAssessmentDrifted),
+	string(tfe.NotificationTriggerAssessmentFailed),
+	string(tfe.NotificationTriggerAssessmentCheckFailed),
+	string(tfe.NotificationTriggerWorkspaceAutoDestroyReminder),
+	string(tfe.NotificationTriggerWorkspaceAutoDestroyRunResults),
+}
+
+// SetNotificationFlags define flags for the cobra command
+func SetNotificationFlags(cmd *cobra.Command) {
+	usage := `The notification configuration ID. Required by read, update, delete and verify.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The workspace ID. Required by list and create.`
+	cmd.Flags().String("workspace-id", "", usage)
+
+	// Create, Update
+	usage = `Used with create and update. A name to identify the notification configuration. Required by create.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `Used with create. Where the notifications are sent: generic (a webhook), slack, microsoft-teams or email.`
+	cmd.Flags().String("destination-type", "", usage)
+
+	usage = `Used with create and update. The URL the notifications are sent to. Required by the generic, slack and microsoft-teams destinations.`
+	cmd.Flags().String("url", "", usage)
+
+	usage = `Used with create, update and listen. With a generic destination, the secret the payloads are signed with, using HMAC-SHA512. With listen, the secret the received payloads are verified with.`
+	cmd.Flags().String("token", "", usage)
+
+	usage = `Used with create and update. The events that send a notification, such as run:completed or run:errored. Can be repeated or comma separated.`
+	var emptyArray []string
+	cmd.Flags().StringSlice("trigger", emptyArray, usage)
+
+	usage = `Used with create and update. Whether the notifications are sent.`
+	cmd.Flags().Bool("enabled", true, usage)
+
+	usage = `Used with create and update. With the email destination, the email addresses of the recipients (Terraform Enterprise only). Can be repeated or comma separated.`
+	cmd.Flags().StringSlice("email-address", emptyArray, usage)
+
+	// Listen
+	usage = `Used with listen. The port the webhook receiver listens on.`
+	cmd.Flags().Int("port", 8080, usage)
+
+	usage = `Used with listen. The address the webhook receiver listens on. Use 0.0.0.0 to accept notifications from other hosts.`
+	cmd.Flags().String("host", "127.0.0.1", usage)
+}
+
+// GetNotificationTriggers returns the triggers given by --trigger
+func GetNotificationTriggers(cmd *cobra.Command) ([]tfe.NotificationTriggerType, error) {
+	values, err := cmd.Flags().GetStringSlice("trigger")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag trigger\n%w", err)
+	}
+
+	var triggers []tfe.NotificationTriggerType
+	for _, value := range values {
+		if !helper.ContainsString(NotificationTriggers, value) {
+			return nil, fmt.Errorf("invalid --trigger %q, valid values are: %s", value, strings.Join(NotificationTriggers, ", "))
+		}
+		triggers = append(triggers, tfe.NotificationTriggerType(value))
+	}
+
+	return triggers, nil
+}
+
+// GetNotificationCreateOptions return options based on the flags values
+func GetNotificationCreateOptions(cmd *cobra.Command) (tfe.NotificationConfigurationCreateOptions, error) {
+	var options tfe.NotificationConfigurationCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+	options.Name = &name
+
+	destinationType, err := cmd.Flags().GetString("destination-type")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag destination-type\n%w", err)
+	}
+
+	if !helper.ContainsString(NotificationDestinationTypes, destinationType) {
+		return options, fmt.Errorf("invalid --destination-type %q, valid values are: %s", destinationType, strings.Join(NotificationDestinationTypes, ", "))
+	}
+	destination := tfe.NotificationDestinationType(destinationType)
+	options.DestinationType = &destination
+
+	enabled, err := cmd.Flags().GetBool("enabled")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag enabled\n%w", err)
+	}
+	options.Enabled = &enabled
+
+	options.Triggers, err = GetNotificationTriggers(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	url, err := cmd.Flags().GetString("url")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag url\n%w", err)
+	}
+
+	token, err := cmd.Flags().GetString("token")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag token\n%w", err)
+	}
+
+	emailAddresses, err := cmd.Flags().GetStringSlice("email-address")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag email-address\n%w", err)
+	}
+
+	if err := checkNotificationDestination(destination, url, token, emailAddresses); err != nil {
+		return options, err
+	}
+
+	if url != "" {
+		options.URL = &url
+	}
+
+	if token != "" {
+		options.Token = &token
+	}
+
+	// an empty list would be sent and clear the addresses
+	if len(emailAddresses) > 0 {
+		options.EmailAddresses = emailAddresses
+	}
+
+	return options, nil
+}
+
+// checkNotificationDestination returns an error if the settings don't fit the destination
+func checkNotificationDestination(destination tfe.NotificationDestinationType, url string, token string, emailAddresses []string) error {
+	switch {
+	case destination != tfe.NotificationDestinationTypeEmail && url == "":
+		return fmt.Errorf("--url is required by the %s destination", destination)
+	case destination == tfe.NotificationDestinationTypeEmail && url != "":
+		return fmt.Errorf("--url can't be used with the email destination")
+	case destination != tfe.NotificationDestinationTypeGeneric && token != "":
+		return fmt.Errorf("--token can only be used with the generic destination, the other destinations don't sign payloads")
+	case destination != tfe.NotificationDestinationTypeEmail && len(emailAddresses) > 0:
+		return fmt.Errorf("--email-address can only be used with the email destination")
+	}
+
+	return nil
+}
+
+// GetNotificationUpdateOptions return options based on the flags values, only the flags given being changed
+func GetNotificationUpdateOptions(cmd *cobra.Command) (tfe.NotificationConfigurationUpdateOptions, error) {
+	var options tfe.NotificationConfigurationUpdateOptions
+
+	for _, flag := range []string{"name", "url", "token"} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag %s\n%w", flag, err)
+		}
+
+		switch flag {
+		case "name":
+			options.Name = &value
+		case "url":
+			options.URL = &value
+		case "token":
+			options.Token = &value
+		}
+	}
+
+	if cmd.Flags().Changed("enabled") {
+		enabled, err := cmd.Flags().GetBool("enabled")
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag enabled\n%w", err)
+		}
+		options.Enabled = &enabled
+	}
+
+	var err error
+	options.Triggers, err = GetNotificationTriggers(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	emailAddresses, err := cmd.Flags().GetStringSlice("email-address")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag email-address\n%w", err)
+	}
+
+	if len(emailAddresses) > 0 {
+		options.EmailAddresses = emailAddresses
+	}
+
+	return options, nil
+}
+
+// RenderNotificationConfigurations renders the notification configurations as a table
+func RenderNotificationConfigurations(list []*tfe.NotificationConfiguration) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tDESTINATION\tENABLED\tTRIGGERS\tURL")
+	for _, nc := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n", nc.ID, nc.Name, nc.DestinationType, nc.Enabled, strings.Join(nc.Triggers, ","), nc.URL)
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+// RenderNotificationDeliveries renders the delivery responses of a notification configuration, such as
+// the result of a verification
+func RenderNotificationDeliveries(nc *tfe.NotificationConfiguration) string {
+	var b strings.Builder
+	if len(nc.DeliveryResponses) == 0 {
+		fmt.Fprintf(&b, "no delivery response recorded for notification configuration %s\n", nc.ID)
+	}
+
+	for _, r := range nc.DeliveryResponses {
+		fmt.Fprintf(&b, "%s -> %s (successful: %s)\n", r.URL, r.Code, r.Successful)
+		if r.Body != "" {
+			fmt.Fprintf(&b, "  %s\n", strings.TrimSpace(r.Body))
+		}
+	}
+
+	return b.String()
+}
+
+// SignNotification returns the signature of a notification payload, the hex encoded HMAC-SHA512 of the body
+func SignNotification(token string, body []byte) string {
+	mac := hmac.New(sha512.New, []byte(token))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyNotificationSignature returns true if the signature is the one of the body with the given token
+func VerifyNotificationSignature(token string, body []byte, signature string) bool {
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha512.New, []byte(token))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), actual)
+}
+
+// RenderNotificationPayload renders a received notification payload for a terminal
+func RenderNotificationPayload(payload model.NotificationPayload, signature string) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "SIGNATURE\t%s\n", signature)
+	fmt.Fprintf(w, "CONFIGURATION\t%s\n", payload.NotificationConfigurationID)
+	if payload.WorkspaceName != "" {
+		fmt.Fprintf(w, "WORKSPACE\t%s/%s (%s)\n", payload.OrganizationName, payload.WorkspaceName, payload.WorkspaceID)
+	}
+	if payload.RunID != "" {
+		fmt.Fprintf(w, "RUN\t%s %q by %s\n", payload.RunID, payload.RunMessage, payload.RunCreatedBy)
+		fmt.Fprintf(w, "URL\t%s\n", payload.RunURL)
+	}
+	for _, n := range payload.Notifications {
+		status := n.RunStatus
+		if status == "" {
+			status = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s %s: %s\n", strings.ToUpper(n.Trigger), status, n.RunUpdatedAt, n.Message)
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+// NewNotificationHandler returns an HTTP handler receiving notification payloads and printing them to out.
+// With a token, a payload without a valid signature is printed, but refused with 401.
+func NewNotificationHandler(token string, out io.Writer) http.Handler {
+	var mu sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxNotificationBodySize))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, fmt.Sprintf("the body is larger than %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, "unable to read the body", http.StatusBadRequest)
+			return
+		}
+
+		signature := "not verified, no --token given"
+		status := http.StatusOK
+		if token != "" {
+			if VerifyNotificationSignature(token, body, r.Header.Get(NotificationSignatureHeader)) {
+				signature = "valid"
+			} else {
+				signature = "INVALID"
+				status = http.StatusUnauthorized
+			}
+		}
+
+		var payload model.NotificationPayload
+		decodeErr := json.Unmarshal(body, &payload)
+
+		mu.Lock()
+		fmt.Fprintf(out, "--- %s %s %s\n", time.Now().Format(time.RFC3339), r.Method, r.URL.Path)
+		if decodeErr != nil {
+			fmt.Fprintf(out, "SIGNATURE  %s\nunable to decode the payload: %v\n%s\n", signature, decodeErr, body)
+		} else {
+			fmt.Fprint(out, RenderNotificationPayload(payload, signature))
+		}
+		mu.Unlock()
+
+		if decodeErr != nil && status == http.StatusOK {
+			status = http.StatusBadRequest
+		}
+		w.WriteHeader(status)
+	})
+}
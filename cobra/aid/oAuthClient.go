@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	tfe "github.com/hashicorp/go-tfe"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -40,13 +39,13 @@ func SetOAuthClientFlags(cmd *cobra.Command) {
 }
 
 // GetOAuthClientCreateOptions return options based on the flags values
-func GetOAuthClientCreateOptions(cmd *cobra.Command) tfe.OAuthClientCreateOptions {
+func GetOAuthClientCreateOptions(cmd *cobra.Command) (tfe.OAuthClientCreateOptions, error) {
 	var options tfe.OAuthClientCreateOptions
 
 	// The base URL of your VCS provider's API.
 	apiURL, err := cmd.Flags().GetString("api-url")
 	if err != nil {
-		logrus.Fatalf("unable to get flag api-url")
+		return options, fmt.Errorf("unable to get flag api-url\n%w", err)
 	}
 
 	if apiURL != "" {
@@ -56,7 +55,7 @@ func GetOAuthClientCreateOptions(cmd *cobra.Command) tfe.OAuthClientCreateOption
 	// The homepage of your VCS provider.
 	httpURL, err := cmd.Flags().GetString("http-url")
 	if err != nil {
-		logrus.Fatalf("unable to get flag http-url")
+		return options, fmt.Errorf("unable to get flag http-url\n%w", err)
 	}
 
 	if httpURL != "" {
@@ -66,7 +65,7 @@ func GetOAuthClientCreateOptions(cmd *cobra.Command) tfe.OAuthClientCreateOption
 	// The token string you were given by your VCS provider.
 	oAuthToken, err := cmd.Flags().GetString("o-auth-token")
 	if err != nil {
-		logrus.Fatalf("unable to get flag o-auth-token")
+		return options, fmt.Errorf("unable to get flag o-auth-token\n%w", err)
 	}
 
 	if oAuthToken != "" {
@@ -76,7 +75,7 @@ func GetOAuthClientCreateOptions(cmd *cobra.Command) tfe.OAuthClientCreateOption
 	// Private key associated with this vcs provider - only available for azure-devops-server
 	privateKey, err := cmd.Flags().GetString("private-key")
 	if err != nil {
-		logrus.Fatalf("unable to get flag private-key")
+		return options, fmt.Errorf("unable to get flag private-key\n%w", err)
 	}
 
 	if privateKey != "" {
@@ -86,7 +85,7 @@ func GetOAuthClientCreateOptions(cmd *cobra.Command) tfe.OAuthClientCreateOption
 	// The VCS provider being connected with.
 	serviceProvider, err := cmd.Flags().GetString("service-provider")
 	if err != nil {
-		logrus.Fatalf("unable to get flag service-provider")
+		return options, fmt.Errorf("unable to get flag service-provider\n%w", err)
 	}
 
 	if serviceProvider != "" {
@@ -126,7 +125,7 @@ func GetOAuthClientCreateOptions(cmd *cobra.Command) tfe.OAuthClientCreateOption
 		options.ServiceProvider = &sp
 	}
 
-	return options
+	return options, nil
 }
 
 // PrintOAuthClientList TODO ...
@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	tfe "github.com/hashicorp/go-tfe"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -33,20 +32,20 @@ func SetOAuthTokenFlags(cmd *cobra.Command) {
 }
 
 // GetOAuthTokenUpdateOptions return options based on the flag values
-func GetOAuthTokenUpdateOptions(cmd *cobra.Command) tfe.OAuthTokenUpdateOptions {
+func GetOAuthTokenUpdateOptions(cmd *cobra.Command) (tfe.OAuthTokenUpdateOptions, error) {
 	var options tfe.OAuthTokenUpdateOptions
 
 	// A private SSH key to be used for git clone operations.
 	privateSSHKey, err := cmd.Flags().GetString("private-ssh-key")
 	if err != nil {
-		logrus.Fatalf("unable to get flag private-ssh-key")
+		return options, fmt.Errorf("unable to get flag private-ssh-key\n%w", err)
 	}
 
 	if privateSSHKey != "" {
 		options.PrivateSSHKey = &privateSSHKey
 	}
 
-	return options
+	return options, nil
 
 }
 
@@ -16,11 +16,208 @@ limitations under the License.
 package aid
 
 import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/awslabs/tecli/cobra/model"
 	"github.com/spf13/cobra"
 )
 
+// Actions of a resource in a plan summary
+const (
+	PlanActionCreate  = "create"
+	PlanActionUpdate  = "update"
+	PlanActionDelete  = "delete"
+	PlanActionReplace = "replace"
+)
+
+// PlanSummaryFormats are the formats supported by plan summary
+var PlanSummaryFormats = []string{"table", "markdown", "json"}
+
+// planActions is the order the actions are listed in
+var planActions = []string{PlanActionCreate, PlanActionUpdate, PlanActionReplace, PlanActionDelete}
+
+const planRootModule = "root"
+
 // SetPlanFlags define flags for the cobra command
 func SetPlanFlags(cmd *cobra.Command) {
 	usage := `The Plan ID`
 	cmd.Flags().String("id", "", usage)
+
+	usage = `Used with summary. Output format: table, markdown or json.`
+	cmd.Flags().String("format", "table", usage)
+
+	usage = `Used with json. Write the JSON execution plan to this file instead of stdout.`
+	cmd.Flags().String("output", "", usage)
+}
+
+// ParsePlanJSON decodes the JSON output of a plan
+func ParsePlanJSON(data []byte) (*model.PlanJSON, error) {
+	var plan model.PlanJSON
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// IsNoopChange returns true if the resource change doesn't modify the resource
+func IsNoopChange(change model.ResourceChange) bool {
+	for _, action := range change.Change.Actions {
+		if action != "no-op" && action != "read" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsDestroyChange returns true if the resource change deletes the resource, replacements included
+func IsDestroyChange(change model.ResourceChange) bool {
+	for _, action := range change.Change.Actions {
+		if action == "delete" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchAddressPattern returns the first pattern matching the given resource address.
+// In a pattern, * matches any sequence of characters, dots and brackets included, and ? a single one.
+func MatchAddressPattern(patterns []string, address string) (string, bool) {
+	for _, pattern := range patterns {
+		if addressPatternRegexp(pattern).MatchString(address) {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+func addressPatternRegexp(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String())
+}
+
+// GetPlanAction returns the summary action of a resource change, or an empty string if it doesn't modify the resource
+func GetPlanAction(change model.ResourceChange) string {
+	if IsNoopChange(change) {
+		return ""
+	}
+
+	if IsDestroyChange(change) {
+		for _, action := range change.Change.Actions {
+			if action == "create" {
+				return PlanActionReplace
+			}
+		}
+
+		return PlanActionDelete
+	}
+
+	return strings.Join(change.Change.Actions, ", ")
+}
+
+// GetPlanSummary groups the resource changes of a plan by module and provider
+func GetPlanSummary(plan *model.PlanJSON) model.PlanSummary {
+	summary := model.PlanSummary{Totals: map[string]int{}}
+	for _, action := range planActions {
+		summary.Totals[action] = 0
+	}
+
+	groups := map[string]int{}
+	for _, change := range plan.ResourceChanges {
+		action := GetPlanAction(change)
+		if action == "" {
+			continue
+		}
+		summary.Totals[action]++
+
+		module := change.ModuleAddress
+		if module == "" {
+			module = planRootModule
+		}
+		provider := strings.TrimPrefix(change.ProviderName, "registry.terraform.io/")
+
+		key := module + " " + provider
+		i, ok := groups[key]
+		if !ok {
+			i = len(summary.Groups)
+			groups[key] = i
+			summary.Groups = append(summary.Groups, model.PlanSummaryGroup{Module: module, Provider: provider})
+		}
+
+		summary.Groups[i].Changes = append(summary.Groups[i].Changes, model.PlanResourceAction{Address: change.Address, Action: action})
+	}
+
+	sort.SliceStable(summary.Groups, func(i, j int) bool {
+		if summary.Groups[i].Module != summary.Groups[j].Module {
+			return summary.Groups[i].Module == planRootModule || (summary.Groups[j].Module != planRootModule && summary.Groups[i].Module < summary.Groups[j].Module)
+		}
+		return summary.Groups[i].Provider < summary.Groups[j].Provider
+	})
+
+	return summary
+}
+
+// FormatPlanSummaryTotals returns the totals of a plan summary, like "2 to create, 0 to update, 1 to replace, 0 to delete"
+func FormatPlanSummaryTotals(summary model.PlanSummary) string {
+	var totals []string
+	for _, action := range planActions {
+		totals = append(totals, fmt.Sprintf("%d to %s", summary.Totals[action], action))
+	}
+
+	return strings.Join(totals, ", ")
+}
+
+// RenderPlanSummary renders a plan summary as a table, markdown or json
+func RenderPlanSummary(summary model.PlanSummary, format string) (string, error) {
+	var out strings.Builder
+
+	switch format {
+	case "json":
+		return ToJSON(summary) + "\n", nil
+	case "markdown":
+		fmt.Fprintf(&out, "**Plan:** %s\n", FormatPlanSummaryTotals(summary))
+		for _, group := range summary.Groups {
+			fmt.Fprintf(&out, "\n#### %s (%s)\n\n", group.Module, group.Provider)
+			out.WriteString("| Action | Resource |\n")
+			out.WriteString("| ------ | -------- |\n")
+			for _, change := range group.Changes {
+				fmt.Fprintf(&out, "| %s | `%s` |\n", change.Action, change.Address)
+			}
+		}
+	case "table":
+		w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MODULE\tPROVIDER\tACTION\tRESOURCE")
+		for _, group := range summary.Groups {
+			for _, change := range group.Changes {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", group.Module, group.Provider, change.Action, change.Address)
+			}
+		}
+		w.Flush()
+		fmt.Fprintf(&out, "\nPlan: %s\n", FormatPlanSummaryTotals(summary))
+	default:
+		return "", fmt.Errorf("unsupported format %q, valid values are: %s", format, strings.Join(PlanSummaryFormats, ", "))
+	}
+
+	return out.String(), nil
 }
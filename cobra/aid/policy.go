@@ -0,0 +1,206 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// PolicyKinds are the valid values of --kind
+var PolicyKinds = []string{string(tfe.Sentinel), string(tfe.OPA)}
+
+// PolicyEnforcementLevels are the valid values of --enforcement-level for each policy kind
+var PolicyEnforcementLevels = map[string][]string{
+	string(tfe.Sentinel): {string(tfe.EnforcementAdvisory), string(tfe.EnforcementSoft), string(tfe.EnforcementHard)},
+	string(tfe.OPA):      {string(tfe.EnforcementAdvisory), string(tfe.EnforcementMandatory)},
+}
+
+// SetPolicyFlags define flags for the cobra command
+func SetPolicyFlags(cmd *cobra.Command) {
+	usage := `The policy ID. Used with read, update, delete, upload and download, instead of --name.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The policy name. Required by create. Used with read, update, delete, upload and download, instead of --id.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `Used with list. A search string (partial policy name) used to filter the results.`
+	cmd.Flags().String("search", "", usage)
+
+	usage = `Used with list and create. The policy language: sentinel or opa. Default sentinel with create.`
+	cmd.Flags().String("kind", "", usage)
+
+	usage = `Used with create and update. The policy description.`
+	cmd.Flags().String("description", "", usage)
+
+	usage = `Used with create and update. advisory, soft-mandatory or hard-mandatory for a Sentinel policy, advisory or mandatory for an OPA policy.`
+	cmd.Flags().String("enforcement-level", string(tfe.EnforcementAdvisory), usage)
+
+	usage = `Used with create and update. The OPA query the policy evaluates, e.g. data.terraform.main.deny. Required by create with --kind opa.`
+	cmd.Flags().String("query", "", usage)
+
+	// Create, Upload
+	usage = `Used with create and upload. The file holding the policy code, uploaded once the policy is created with create.`
+	cmd.Flags().String("file", "", usage)
+
+	// Download
+	usage = `Used with download. File the policy code is written to, instead of stdout.`
+	cmd.Flags().String("out", "", usage)
+}
+
+// GetPolicyKind returns --kind, sentinel by default
+func GetPolicyKind(cmd *cobra.Command) (tfe.PolicyKind, error) {
+	kind, err := cmd.Flags().GetString("kind")
+	if err != nil {
+		return "", fmt.Errorf("unable to get flag kind\n%w", err)
+	}
+
+	if kind == "" {
+		return tfe.Sentinel, nil
+	}
+
+	if !helper.ContainsString(PolicyKinds, kind) {
+		return "", fmt.Errorf("invalid --kind %q, valid values are: %s", kind, strings.Join(PolicyKinds, ", "))
+	}
+
+	return tfe.PolicyKind(kind), nil
+}
+
+// GetPolicyEnforcementLevelFlag returns --enforcement-level after checking it is valid for the policy kind
+func GetPolicyEnforcementLevelFlag(cmd *cobra.Command, kind tfe.PolicyKind) (tfe.EnforcementLevel, error) {
+	level, err := cmd.Flags().GetString("enforcement-level")
+	if err != nil {
+		return "", fmt.Errorf("unable to get flag enforcement-level\n%w", err)
+	}
+
+	levels := PolicyEnforcementLevels[string(kind)]
+	if !helper.ContainsString(levels, level) {
+		return "", fmt.Errorf("invalid --enforcement-level %q for %s policies, valid values are: %s", level, kind, strings.Join(levels, ", "))
+	}
+
+	return tfe.EnforcementLevel(level), nil
+}
+
+// GetPolicyCreateOptions return options based on the flags values
+func GetPolicyCreateOptions(cmd *cobra.Command) (tfe.PolicyCreateOptions, error) {
+	var options tfe.PolicyCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+	options.Name = &name
+
+	options.Kind, err = GetPolicyKind(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	level, err := GetPolicyEnforcementLevelFlag(cmd, options.Kind)
+	if err != nil {
+		return options, err
+	}
+	options.EnforcementLevel = &level
+
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag description\n%w", err)
+	}
+	if description != "" {
+		options.Description = &description
+	}
+
+	query, err := cmd.Flags().GetString("query")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag query\n%w", err)
+	}
+	if query != "" {
+		if options.Kind != tfe.OPA {
+			return options, fmt.Errorf("--query is only used by OPA policies")
+		}
+		options.Query = &query
+	}
+
+	if options.Kind == tfe.OPA && query == "" {
+		return options, fmt.Errorf("--query must be defined for an OPA policy")
+	}
+
+	return options, nil
+}
+
+// GetPolicyUpdateOptions return options based on the flags values. The kind of a policy can't change,
+// the enforcement level is checked against it.
+func GetPolicyUpdateOptions(cmd *cobra.Command, kind tfe.PolicyKind) (tfe.PolicyUpdateOptions, error) {
+	var options tfe.PolicyUpdateOptions
+
+	if cmd.Flags().Changed("enforcement-level") {
+		level, err := GetPolicyEnforcementLevelFlag(cmd, kind)
+		if err != nil {
+			return options, err
+		}
+		options.EnforcementLevel = &level
+	}
+
+	if cmd.Flags().Changed("description") {
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag description\n%w", err)
+		}
+		options.Description = &description
+	}
+
+	if cmd.Flags().Changed("query") {
+		if kind != tfe.OPA {
+			return options, fmt.Errorf("--query is only used by OPA policies")
+		}
+
+		query, err := cmd.Flags().GetString("query")
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag query\n%w", err)
+		}
+		options.Query = &query
+	}
+
+	return options, nil
+}
+
+// GetPolicyEnforcement returns the enforcement level of a policy, read from the enforce attribute of the
+// policies created before the enforcement-level attribute existed
+func GetPolicyEnforcement(p *tfe.Policy) tfe.EnforcementLevel {
+	if p.EnforcementLevel != "" || len(p.Enforce) == 0 {
+		return p.EnforcementLevel
+	}
+
+	return p.Enforce[0].Mode
+}
+
+// RenderPolicies renders the policies as a table
+func RenderPolicies(list []*tfe.Policy) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tKIND\tENFORCEMENT\tPOLICY SETS\tDESCRIPTION")
+	for _, p := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", p.ID, p.Name, p.Kind, GetPolicyEnforcement(p), p.PolicySetCount, p.Description)
+	}
+	w.Flush()
+
+	return b.String()
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// PolicyCheckFormats are the output formats of policy-check list and override
+var PolicyCheckFormats = []string{"table", "json"}
+
+// SetPolicyCheckFlags define flags for the cobra command
+func SetPolicyCheckFlags(cmd *cobra.Command) {
+	usage := `The Policy Check ID`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `Used with list. The ID of the run whose policy checks are listed.`
+	cmd.Flags().String("run-id", "", usage)
+
+	usage = `Used with override. Why the failed policies are overridden. Posted as a comment on the run and recorded in the audit journal.`
+	cmd.Flags().String("reason", "", usage)
+
+	usage = `Used with list and override. Output format: table or json.`
+	cmd.Flags().String("format", "table", usage)
+}
+
+// ParseSentinelResult decodes the sentinel attribute of a policy check result
+func ParseSentinelResult(sentinel interface{}) (*model.SentinelResult, error) {
+	var result model.SentinelResult
+	if sentinel == nil {
+		return &result, nil
+	}
+
+	b, err := json.Marshal(sentinel)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the sentinel result\n%w", err)
+	}
+
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, fmt.Errorf("unable to decode the sentinel result\n%w", err)
+	}
+
+	return &result, nil
+}
+
+// GetPolicyEnforcementLevel returns the enforcement level of a policy. Older results don't
+// include it, only whether the policy may fail and whether its policy set can be overridden:
+// a failed policy of a set that can be overridden is soft-mandatory, the level of the other
+// mandatory policies can't be told apart and is reported as unknown.
+func GetPolicyEnforcementLevel(policy model.SentinelPolicy, canOverride bool) string {
+	switch {
+	case policy.EnforcementLevel != "":
+		return policy.EnforcementLevel
+	case policy.AllowedFailure:
+		return "advisory"
+	case !policy.Result && canOverride:
+		return "soft-mandatory"
+	default:
+		return "unknown"
+	}
+}
+
+// RenderPolicyChecks renders the result of every policy of the given policy checks as a table
+func RenderPolicyChecks(checks []*tfe.PolicyCheck) (string, error) {
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tSCOPE\tSTATUS\tPOLICY\tENFORCEMENT\tRESULT")
+	for _, pc := range checks {
+		var sentinel *model.SentinelResult
+		if pc.Result != nil {
+			var err error
+			sentinel, err = ParseSentinelResult(pc.Result.Sentinel)
+			if err != nil {
+				return "", fmt.Errorf("policy check %s\n%w", pc.ID, err)
+			}
+		}
+
+		if sentinel == nil || len(sentinel.Data) == 0 {
+			fmt.Fprintf(w, "%s\t%s\t%s\t-\t-\t-\n", pc.ID, pc.Scope, pc.Status)
+			continue
+		}
+
+		// map order is random, policy sets are listed by name
+		sets := make([]string, 0, len(sentinel.Data))
+		for name := range sentinel.Data {
+			sets = append(sets, name)
+		}
+		sort.Strings(sets)
+
+		for _, name := range sets {
+			set := sentinel.Data[name]
+			for _, policy := range set.Policies {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", pc.ID, pc.Scope, pc.Status, policy.Policy, GetPolicyEnforcementLevel(policy, set.CanOverride), formatPolicyResult(policy))
+			}
+		}
+	}
+	w.Flush()
+
+	return out.String(), nil
+}
+
+func formatPolicyResult(policy model.SentinelPolicy) string {
+	switch {
+	case policy.Error != nil:
+		return "errored"
+	case policy.Result:
+		return "passed"
+	default:
+		return "failed"
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// SetPolicySetFlags define flags for the cobra command
+func SetPolicySetFlags(cmd *cobra.Command) {
+	usage := `The policy set ID. Used instead of --name, except with list and create.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The policy set name. Required by create. Used instead of --id with the other arguments.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `Used with list. A search string (partial policy set name) used to filter the results.`
+	cmd.Flags().String("search", "", usage)
+
+	// Create, Update
+	usage = `Used with list and create. The policy language of the set: sentinel or opa. Default sentinel with create.`
+	cmd.Flags().String("kind", "", usage)
+
+	usage = `Used with update. A new name for the policy set.`
+	cmd.Flags().String("new-name", "", usage)
+
+	usage = `Used with create and update. The policy set description.`
+	cmd.Flags().String("description", "", usage)
+
+	usage = `Used with create and update. Whether the policy set applies to every workspace of the organization.`
+	cmd.Flags().Bool("global", false, usage)
+
+	usage = `Used with create and update of an OPA policy set. Whether the failed mandatory policies can be overridden.`
+	cmd.Flags().Bool("overridable", false, usage)
+
+	usage = `Used with create and update of a VCS-backed policy set. The subdirectory of the repository holding the policies.`
+	cmd.Flags().String("policies-path", "", usage)
+
+	// Create, Update: VCS-backed policy sets
+	SetVCSRepoFlags(cmd)
+
+	// Create, AddWorkspace, RemoveWorkspace, AddPolicy, RemovePolicy
+	usage = `Used with create, add-workspace and remove-workspace. Names of workspaces, or glob patterns such as app-* matching workspace names. Can be repeated or comma separated.`
+	var emptyArray []string
+	cmd.Flags().StringSlice("workspace", emptyArray, usage)
+
+	usage = `Used with create, add-policy and remove-policy. Names of policies of the organization. Can be repeated or comma separated.`
+	cmd.Flags().StringSlice("policy", emptyArray, usage)
+
+	// Parameters
+	usage = `Used with set-parameter and delete-parameter. The parameter key.`
+	cmd.Flags().String("key", "", usage)
+
+	usage = `Used with set-parameter. The parameter value.`
+	cmd.Flags().String("value", "", usage)
+
+	usage = `Used with set-parameter. Whether the value is sensitive. A sensitive value can't be read back.`
+	cmd.Flags().Bool("sensitive", false, usage)
+
+	// Upload
+	usage = `Used with upload. The local directory of policies published as a new version of the policy set.`
+	cmd.Flags().String("dir", "", usage)
+
+	usage = `Used with upload. How long to wait for the new version to be ingested.`
+	cmd.Flags().Duration("timeout", 2*time.Minute, usage)
+}
+
+// GetPolicySetCreateOptions return options based on the flags values. The workspaces and policies given
+// by name are resolved by the caller.
+func GetPolicySetCreateOptions(cmd *cobra.Command) (tfe.PolicySetCreateOptions, error) {
+	var options tfe.PolicySetCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+	options.Name = &name
+
+	options.Kind, err = GetPolicyKind(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	settings, err := getPolicySetSettings(cmd, options.Kind)
+	if err != nil {
+		return options, err
+	}
+	options.Description = settings.Description
+	options.Global = settings.Global
+	options.Overridable = settings.Overridable
+	options.PoliciesPath = settings.PoliciesPath
+	options.VCSRepo = settings.VCSRepo
+
+	return options, nil
+}
+
+// GetPolicySetUpdateOptions return options based on the flags values. The kind of a policy set can't change,
+// the settings are checked against it.
+func GetPolicySetUpdateOptions(cmd *cobra.Command, kind tfe.PolicyKind) (tfe.PolicySetUpdateOptions, error) {
+	options, err := getPolicySetSettings(cmd, kind)
+	if err != nil {
+		return options, err
+	}
+
+	newName, err := cmd.Flags().GetString("new-name")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag new-name\n%w", err)
+	}
+	if newName != "" {
+		options.Name = &newName
+	}
+
+	return options, nil
+}
+
+// getPolicySetSettings returns the settings shared by create and update, only the ones given
+func getPolicySetSettings(cmd *cobra.Command, kind tfe.PolicyKind) (tfe.PolicySetUpdateOptions, error) {
+	var options tfe.PolicySetUpdateOptions
+
+	if cmd.Flags().Changed("description") {
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag description\n%w", err)
+		}
+		options.Description = &description
+	}
+
+	if cmd.Flags().Changed("global") {
+		global, err := cmd.Flags().GetBool("global")
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag global\n%w", err)
+		}
+		options.Global = &global
+	}
+
+	if cmd.Flags().Changed("overridable") {
+		if kind != tfe.OPA {
+			return options, fmt.Errorf("--overridable is only used by OPA policy sets")
+		}
+
+		overridable, err := cmd.Flags().GetBool("overridable")
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag overridable\n%w", err)
+		}
+		options.Overridable = &overridable
+	}
+
+	repoOptions, err := GetVCSRepoFlags(cmd)
+	if err != nil {
+		return options, err
+	}
+	if repoOptions != (tfe.VCSRepoOptions{}) {
+		options.VCSRepo = &repoOptions
+	}
+
+	policiesPath, err := cmd.Flags().GetString("policies-path")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag policies-path\n%w", err)
+	}
+	if policiesPath != "" {
+		if options.VCSRepo == nil {
+			return options, fmt.Errorf("--policies-path is only used with a VCS repository, see --vcs-repo-identifier")
+		}
+		options.PoliciesPath = &policiesPath
+	}
+
+	return options, nil
+}
+
+// GetPolicySetDir returns --dir after checking it is a directory holding policies
+func GetPolicySetDir(cmd *cobra.Command) (string, error) {
+	dir, err := cmd.Flags().GetString("dir")
+	if err != nil {
+		return "", fmt.Errorf("unable to get flag dir\n%w", err)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("unable to read --dir %s\n%w", dir, err)
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("invalid --dir %q, it must be a directory", dir)
+	}
+
+	// a Sentinel policy set is described by sentinel.hcl, an OPA one by policies.hcl
+	for _, config := range []string{"sentinel.hcl", "policies.hcl"} {
+		if _, err := os.Stat(filepath.Join(dir, config)); err == nil {
+			return dir, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid --dir %q, it holds no sentinel.hcl or policies.hcl describing the policies", dir)
+}
+
+// RenderPolicySets renders the policy sets as a table
+func RenderPolicySets(list []*tfe.PolicySet) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tKIND\tGLOBAL\tPOLICIES\tWORKSPACES\tSOURCE")
+	for _, ps := range list {
+		source := "api"
+		if ps.VCSRepo != nil {
+			source = ps.VCSRepo.Identifier
+			if ps.PoliciesPath != "" {
+				source += "//" + ps.PoliciesPath
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%d\t%d\t%s\n", ps.ID, ps.Name, ps.Kind, ps.Global, ps.PolicyCount, ps.WorkspaceCount, source)
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+// RenderPolicySetParameters renders the parameters of a policy set as a table, the sensitive values hidden
+func RenderPolicySetParameters(list []*tfe.PolicySetParameter) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tKEY\tVALUE\tSENSITIVE")
+	for _, p := range list {
+		value := p.Value
+		if p.Sensitive {
+			value = "(sensitive)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", p.ID, p.Key, value, p.Sensitive)
+	}
+	w.Flush()
+
+	return b.String()
+}
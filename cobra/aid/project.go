@@ -0,0 +1,107 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// SetProjectFlags define flags for the cobra command
+func SetProjectFlags(cmd *cobra.Command) {
+	usage := `The project ID. Used with read, update and delete, instead of --name.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The project name. Required by create. Used with read, update and delete, instead of --id.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `Used with list. A search string (partial project name) used to filter the results.`
+	cmd.Flags().String("search", "", usage)
+
+	// Create, Update
+	usage = `Used with update. A new name for the project.`
+	cmd.Flags().String("new-name", "", usage)
+
+	usage = `Used with create and update. The project description.`
+	cmd.Flags().String("description", "", usage)
+}
+
+// IsProjectID returns true if the given --project value is a project ID rather than a project name
+func IsProjectID(value string) bool {
+	return strings.HasPrefix(value, "prj-")
+}
+
+// GetProjectCreateOptions return options based on the flags values
+func GetProjectCreateOptions(cmd *cobra.Command) (tfe.ProjectCreateOptions, error) {
+	var options tfe.ProjectCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+	options.Name = name
+
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag description\n%w", err)
+	}
+	if description != "" {
+		options.Description = &description
+	}
+
+	return options, nil
+}
+
+// GetProjectUpdateOptions return options based on the flags values
+func GetProjectUpdateOptions(cmd *cobra.Command) (tfe.ProjectUpdateOptions, error) {
+	var options tfe.ProjectUpdateOptions
+
+	newName, err := cmd.Flags().GetString("new-name")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag new-name\n%w", err)
+	}
+	if newName != "" {
+		options.Name = &newName
+	}
+
+	// an empty description clears it
+	if cmd.Flags().Changed("description") {
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag description\n%w", err)
+		}
+		options.Description = &description
+	}
+
+	return options, nil
+}
+
+// RenderProjects renders the projects as a table
+func RenderProjects(list []*tfe.Project) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tDESCRIPTION")
+	for _, p := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.ID, p.Name, p.Description)
+	}
+	w.Flush()
+
+	return b.String()
+}
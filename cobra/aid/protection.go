@@ -0,0 +1,40 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"path"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MatchProtectedPattern returns the first pattern matching the given workspace name
+func MatchProtectedPattern(patterns []string, workspace string) (string, bool) {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, workspace)
+		if err != nil {
+			// a malformed pattern must not silently disable the guardrail
+			logrus.Errorf("invalid protected workspace pattern %q\n%v", pattern, err)
+			return pattern, true
+		}
+
+		if matched {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,146 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/awslabs/tecli/box"
+	"github.com/awslabs/tecli/cobra/model"
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// RunReportFormats are the built-in templates of run report
+var RunReportFormats = []string{"markdown", "html"}
+
+var runReportTemplates = map[string]string{
+	"markdown": "/template/run-report.md",
+	"html":     "/template/run-report.html",
+}
+
+// GetTFEAddress returns the address of Terraform Cloud or Terraform Enterprise, as used by go-tfe
+func GetTFEAddress() string {
+	if address := os.Getenv("TFE_ADDRESS"); address != "" {
+		return strings.TrimSuffix(address, "/")
+	}
+
+	return tfe.DefaultAddress
+}
+
+// NewRunReport builds the data of a run report. The plan summary, cost estimate and policy checks are optional.
+func NewRunReport(organization string, run *tfe.Run, plan *tfe.Plan, summary model.PlanSummary, policyChecks []*tfe.PolicyCheck) model.RunReport {
+	report := model.RunReport{
+		Organization: organization,
+		Run: model.RunReportRun{
+			ID:        run.ID,
+			Status:    string(run.Status),
+			Message:   run.Message,
+			Source:    string(run.Source),
+			IsDestroy: run.IsDestroy,
+			CreatedAt: run.CreatedAt,
+		},
+		Changes: summary,
+	}
+
+	if run.Workspace != nil {
+		workspaceURL := fmt.Sprintf("%s/app/%s/workspaces/%s", GetTFEAddress(), organization, run.Workspace.Name)
+		report.Workspace = model.RunReportWorkspace{ID: run.Workspace.ID, Name: run.Workspace.Name, URL: workspaceURL}
+		report.Run.URL = fmt.Sprintf("%s/runs/%s", workspaceURL, run.ID)
+	}
+
+	if plan != nil {
+		report.Plan = model.RunReportPlan{
+			ID:           plan.ID,
+			Status:       string(plan.Status),
+			HasChanges:   plan.HasChanges,
+			Additions:    plan.ResourceAdditions,
+			Changes:      plan.ResourceChanges,
+			Destructions: plan.ResourceDestructions,
+			Imports:      plan.ResourceImports,
+		}
+	}
+
+	if ce := run.CostEstimate; ce != nil && ce.Status == tfe.CostEstimateFinished {
+		report.CostEstimate = &model.RunReportCostEstimate{
+			Status:              string(ce.Status),
+			PriorMonthlyCost:    ce.PriorMonthlyCost,
+			ProposedMonthlyCost: ce.ProposedMonthlyCost,
+			DeltaMonthlyCost:    ce.DeltaMonthlyCost,
+		}
+	}
+
+	for _, pc := range policyChecks {
+		check := model.RunReportPolicyCheck{ID: pc.ID, Scope: string(pc.Scope), Status: string(pc.Status)}
+		if pc.Result != nil {
+			check.Passed = pc.Result.Passed
+			check.AdvisoryFailed = pc.Result.AdvisoryFailed
+			check.SoftFailed = pc.Result.SoftFailed
+			check.HardFailed = pc.Result.HardFailed
+		}
+		report.PolicyChecks = append(report.PolicyChecks, check)
+	}
+
+	return report
+}
+
+// GetRunReportTemplate returns the template given by --template or, if empty, the built-in template of the format
+func GetRunReportTemplate(format string, path string) (string, error) {
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("unable to read template %s\n%w", path, err)
+		}
+		return string(b), nil
+	}
+
+	b, found := box.Get(runReportTemplates[format])
+	if !found {
+		return "", fmt.Errorf("unsupported format %q, valid values are: %s", format, strings.Join(RunReportFormats, ", "))
+	}
+
+	return string(b), nil
+}
+
+// RenderRunReport renders the report with the given template. HTML templates escape the values they print.
+func RenderRunReport(report model.RunReport, format string, text string) (string, error) {
+	var out bytes.Buffer
+
+	if format == "html" {
+		tmpl, err := htmltemplate.New("report").Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse template\n%w", err)
+		}
+		if err := tmpl.Execute(&out, report); err != nil {
+			return "", fmt.Errorf("unable to render template\n%w", err)
+		}
+		return out.String(), nil
+	}
+
+	tmpl, err := template.New("report").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse template\n%w", err)
+	}
+	if err := tmpl.Execute(&out, report); err != nil {
+		return "", fmt.Errorf("unable to render template\n%w", err)
+	}
+
+	return out.String(), nil
+}
@@ -17,6 +17,7 @@ package aid
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/awslabs/tecli/cobra/model"
@@ -51,6 +52,11 @@ func GetAppInfo() model.App {
 	app.LogsFilePath = app.ConfigurationsDir + sep + app.LogsFileName + "." + app.LogsFileType
 	app.LogsFilePermissions = os.ModePerm
 
+	// ~/.tecli/audit.json
+	app.AuditFileName = "audit"
+	app.AuditFileType = "json"
+	app.AuditFilePath = app.ConfigurationsDir + sep + app.AuditFileName + "." + app.AuditFileType
+
 	app.WorkingDir, err = os.Getwd()
 	if err != nil {
 		// Exit here is acceptable: without a working directory, none of the
@@ -88,6 +94,7 @@ func LoadViper() {
 	viper.BindEnv("USER_TOKEN")
 	viper.BindEnv("TEAM_TOKEN")
 	viper.BindEnv("ORGANIZATION_TOKEN")
+	viper.BindEnv("AUDIT_SINK")
 
 	app := GetAppInfo()
 
@@ -99,7 +106,8 @@ func LoadViper() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("using config file:", viper.ConfigFileUsed())
+		// not printed on stdout, where commands write documents such as outputs or states
+		logrus.Debugf("using config file: %s", viper.ConfigFileUsed())
 	}
 	// if config is not found, that's okay, as the user might use env vars
 }
@@ -120,6 +128,12 @@ func getTFEConfig(token string) *tfe.Config {
 	config := &tfe.Config{
 		Token: token,
 	}
+
+	if debugHTTP {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		config.HTTPClient = &http.Client{Transport: NewLoggingRoundTripper(transport)}
+	}
+
 	return config
 }
 
@@ -135,14 +149,14 @@ func getTFENewClient(config *tfe.Config) (*tfe.Client, error) {
 }
 
 // GetTFEClient returns a new terraform api client given a token.
-// Fatal here is acceptable: without a working TFE client every command in
-// this CLI is a no-op, and there is no useful recovery path.
-func GetTFEClient(token string) *tfe.Client {
+// The error is returned rather than fatal, so the failure of a mutating
+// command is still recorded in the audit journal.
+func GetTFEClient(token string) (*tfe.Client, error) {
 	config := getTFEConfig(token)
 	client, err := getTFENewClient(config)
 	if err != nil {
-		logrus.Fatalf("unable to get terraform cloud api client\n%v\n", err)
+		return nil, fmt.Errorf("unable to get terraform cloud api client\n%w", err)
 	}
 
-	return client
+	return client, nil
 }
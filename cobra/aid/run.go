@@ -17,9 +17,16 @@ package aid
 
 import (
 	"fmt"
-
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
 	tfe "github.com/hashicorp/go-tfe"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -49,17 +56,376 @@ func SetRunFlags(cmd *cobra.Command) {
 	usage = `An optional comment about the run.`
 	cmd.Flags().String("comment", "", usage)
 
+	usage = `Used with create. Only refresh the state, ignoring the configuration changes.`
+	cmd.Flags().Bool("refresh-only", false, usage)
+
+	usage = `Used with create. Update the state prior to checking for differences. Use --refresh=false to skip the refresh.`
+	cmd.Flags().Bool("refresh", true, usage)
+
+	usage = `Used with create. Resource addresses to destroy and re-create, even if their configuration didn't change.`
+	cmd.Flags().StringArray("replace-addrs", []string{}, usage)
+
+	usage = `Used with create. Create a speculative, plan-only run that can't be applied.`
+	cmd.Flags().Bool("plan-only", false, usage)
+
+	usage = `Used with create. Allow applying the run even when its plan has no changes, e.g. to upgrade the state after a Terraform upgrade.`
+	cmd.Flags().Bool("allow-empty-apply", false, usage)
+
+	usage = `Used with create. Apply the run without confirmation. Defaults to the auto-apply setting of the workspace.`
+	cmd.Flags().Bool("auto-apply", false, usage)
+
+	usage = `Used with create and --plan-only. Terraform version of the run, e.g. to test an upgrade.`
+	cmd.Flags().String("terraform-version", "", usage)
+
+	usage = `Used with create. Run-scoped Terraform variable as key=value, prioritized over the workspace variables. Can be repeated.`
+	cmd.Flags().StringArray("var", []string{}, usage)
+
+	usage = `Wait for the plan of the created run to finish and print a summary of the changes.`
+	cmd.Flags().Bool("wait", false, usage)
+
+	usage = `Used with --wait. Exit with 0 when the plan has no changes, 2 when it has changes and 1 when it fails.`
+	cmd.Flags().Bool("detailed-exitcode", false, usage)
+
+	usage = `Used with --wait. Discard the run when its plan has no changes, so it doesn't stay pending.`
+	cmd.Flags().Bool("discard-if-no-changes", false, usage)
+
+	usage = `Used with create --wait and ps --watch. How often to poll the run status.`
+	cmd.Flags().Duration("poll-interval", 5*time.Second, usage)
+
+	usage = `Used with create --wait. How long to wait for the plan before failing.`
+	cmd.Flags().Duration("timeout", time.Hour, usage)
+
+	usage = `Used with ps. Refresh the table in place until interrupted.`
+	cmd.Flags().Bool("watch", false, usage)
+
+	usage = `Used with ps, cancel-all, force-cancel-all and discard-all. Number of API calls made at the same time.`
+	cmd.Flags().Int("concurrency", 8, usage)
+
+	usage = `Used with cancel-all, force-cancel-all and discard-all. Only select runs whose message matches this regular expression.`
+	cmd.Flags().String("message-regex", "", usage)
+
+	usage = `Used with apply. Refuse to apply if the plan destroys or replaces any resource.`
+	cmd.Flags().Bool("if-no-destroy", false, usage)
+
+	usage = `Used with apply. Refuse to apply if the plan adds, changes and destroys more than this number of resources. -1 disables the check.`
+	cmd.Flags().Int("max-changes", -1, usage)
+
+	usage = `Used with apply. Refuse to apply if the plan changes a resource whose address matches one of these globs, e.g. 'aws_iam_*' or 'module.db.*'.`
+	cmd.Flags().StringArray("deny-addrs", []string{}, usage)
+
+	usage = `Used with apply. Refuse to apply if the run's configuration version wasn't built from this commit.`
+	cmd.Flags().String("expected-commit-sha", "", usage)
+
+	usage = `Used with apply. Refuse to apply if the cost estimate increases the monthly cost by more than this amount, e.g. 100.`
+	cmd.Flags().Float64("max-delta", 0, usage)
+
+	usage = `Used with list. Show the runs as a table with the monthly cost of their cost estimate.`
+	cmd.Flags().Bool("with-cost", false, usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Only select runs with these statuses, e.g. applied,errored.`
+	cmd.Flags().StringSlice("status", []string{}, usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Only select runs with these operations: plan-and-apply, plan-only, destroy, refresh-only, save-plan or empty-apply.`
+	cmd.Flags().StringSlice("operation", []string{}, usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Only select runs from these sources: ui, api, vcs or cli.`
+	cmd.Flags().StringSlice("source", []string{}, usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Only select runs of the given commit SHA.`
+	cmd.Flags().String("commit", "", usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Only select runs of the given VCS username.`
+	cmd.Flags().String("user", "", usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Only select runs created after the given duration ago (e.g. 24h) or RFC3339 timestamp.`
+	cmd.Flags().String("since", "", usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Only select runs created before the given duration ago (e.g. 1h) or RFC3339 timestamp.`
+	cmd.Flags().String("until", "", usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Sort order: created-at (newest first) or status.`
+	cmd.Flags().String("sort", "created-at", usage)
+
+	usage = `Used with list, cancel-all, force-cancel-all and discard-all. Maximum number of runs, the most recent ones. 0 selects every run. Defaults to every run with the -all arguments.`
+	cmd.Flags().Int("limit", 20, usage)
+
+	usage = `Used with report. Built-in template to render: markdown or html.`
+	cmd.Flags().String("format", "markdown", usage)
+
+	usage = `Used with report. Path to a Go template file to render instead of the built-in one.`
+	cmd.Flags().String("template", "", usage)
+
+}
+
+// RunListSorts are the sort orders supported by run list
+var RunListSorts = []string{"created-at", "status"}
+
+// runSourceAliases maps the sources given to run list to the run sources of the API
+var runSourceAliases = map[string][]tfe.RunSource{
+	"ui":  {tfe.RunSourceUI},
+	"api": {tfe.RunSourceAPI},
+	"vcs": {tfe.RunSourceConfigurationVersion},
+	"cli": {"terraform", "terraform+cloud"},
+}
+
+// RunListFilter are the filters of run list the API doesn't support
+type RunListFilter struct {
+	Since time.Time
+	Until time.Time
+	Sort  string
+	Limit int
+}
+
+// runPlanSettledStatuses are the statuses of a run whose plan has finished
+var runPlanSettledStatuses = []tfe.RunStatus{
+	tfe.RunPlanned,
+	tfe.RunPlannedAndFinished,
+	tfe.RunPlannedAndSaved,
+	tfe.RunCostEstimated,
+	tfe.RunPolicyChecked,
+	tfe.RunPolicyOverride,
+	tfe.RunPolicySoftFailed,
+	tfe.RunPostPlanAwaitingDecision,
+	tfe.RunPostPlanCompleted,
+	tfe.RunConfirmed,
+	tfe.RunQueuingApply,
+	tfe.RunApplyQueued,
+	tfe.RunPreApplyRunning,
+	tfe.RunPreApplyCompleted,
+	tfe.RunApplying,
+	tfe.RunPostApplyRunning,
+	tfe.RunPostApplyCompleted,
+	tfe.RunApplied,
+}
+
+// runFailedStatuses are the statuses of a run that ended without a usable plan
+var runFailedStatuses = []tfe.RunStatus{
+	tfe.RunErrored,
+	tfe.RunCanceled,
+	tfe.RunDiscarded,
+	"force_canceled",
+}
+
+// runFinalStatuses are the statuses of a run that won't change anymore
+var runFinalStatuses = []tfe.RunStatus{
+	tfe.RunApplied,
+	tfe.RunPlannedAndFinished,
+	tfe.RunPlannedAndSaved,
+	tfe.RunErrored,
+	tfe.RunCanceled,
+	tfe.RunDiscarded,
+	"force_canceled",
+}
+
+// runActiveStatuses are the statuses of a run that may still change, the only ones that can be
+// canceled, force-canceled or discarded
+var runActiveStatuses = []tfe.RunStatus{
+	tfe.RunPending,
+	tfe.RunFetching,
+	tfe.RunFetchingCompleted,
+	tfe.RunPrePlanRunning,
+	tfe.RunPrePlanCompleted,
+	tfe.RunQueuing,
+	tfe.RunPlanQueued,
+	tfe.RunPlanning,
+	tfe.RunPlanned,
+	tfe.RunCostEstimating,
+	tfe.RunCostEstimated,
+	tfe.RunPolicyChecking,
+	tfe.RunPolicyOverride,
+	tfe.RunPolicySoftFailed,
+	tfe.RunPolicyChecked,
+	tfe.RunPostPlanRunning,
+	tfe.RunPostPlanCompleted,
+	tfe.RunPostPlanAwaitingDecision,
+	tfe.RunConfirmed,
+	tfe.RunQueuingApply,
+	tfe.RunApplyQueued,
+	tfe.RunPreApplyRunning,
+	tfe.RunPreApplyCompleted,
+	tfe.RunApplying,
+	tfe.RunPostApplyRunning,
+	tfe.RunPostApplyCompleted,
+}
+
+// GetRunActiveStatusFilter returns the active statuses as the value of the status filter of the
+// runs API, so the runs that already ended aren't listed
+func GetRunActiveStatusFilter() string {
+	statuses := make([]string, len(runActiveStatuses))
+	for i, status := range runActiveStatuses {
+		statuses[i] = string(status)
+	}
+
+	return strings.Join(statuses, ",")
+}
+
+// RunActionResult is the outcome of an action on a single run
+type RunActionResult struct {
+	RunID  string
+	Status string
+	Action string
+	Err    error
+}
+
+// RunDashboardRow is a line of the run ps table
+type RunDashboardRow struct {
+	Workspace string
+	Run       *tfe.Run
+}
+
+// GetRunMessageRegex return the regular expression given by --message-regex, or nil
+func GetRunMessageRegex(cmd *cobra.Command) (*regexp.Regexp, error) {
+	messageRegex, err := cmd.Flags().GetString("message-regex")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag message-regex\n%w", err)
+	}
+
+	if messageRegex == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(messageRegex)
+	if err != nil {
+		// not wrapped, the error is about the flag value rather than a failure
+		return nil, fmt.Errorf("invalid --message-regex %q\n%v", messageRegex, err)
+	}
+
+	return re, nil
+}
+
+// IsRunActionable returns true if the run allows the action: cancel, force-cancel or discard
+func IsRunActionable(run *tfe.Run, action string) bool {
+	if run.Actions == nil {
+		return false
+	}
+
+	switch action {
+	case "cancel":
+		return run.Actions.IsCancelable
+	case "force-cancel":
+		return run.Actions.IsForceCancelable
+	case "discard":
+		return run.Actions.IsDiscardable
+	}
+
+	return false
+}
+
+// RenderRunActionResults renders the outcome of every action as a table
+func RenderRunActionResults(results []RunActionResult) string {
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RUN\tSTATUS\tACTION\tRESULT\tERROR")
+	for _, result := range results {
+		outcome, message := "ok", ""
+		if result.Err != nil {
+			outcome = "failed"
+			message = strings.Join(strings.Fields(result.Err.Error()), " ")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", result.RunID, result.Status, result.Action, outcome, message)
+	}
+	w.Flush()
+
+	return out.String()
+}
+
+// IsRunActive returns true if the run is running, queued or waiting for a decision
+func IsRunActive(status tfe.RunStatus) bool {
+	return !containsRunStatus(runFinalStatuses, status)
+}
+
+// FormatAge returns a short human readable duration, like 45s, 12m, 3h or 2d
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// RenderRunDashboard renders the active runs as a table, sorted by workspace then age
+func RenderRunDashboard(rows []RunDashboardRow, now time.Time) string {
+	if len(rows) == 0 {
+		return "no active run\n"
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Workspace != rows[j].Workspace {
+			return rows[i].Workspace < rows[j].Workspace
+		}
+		return rows[i].Run.CreatedAt.Before(rows[j].Run.CreatedAt)
+	})
+
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WORKSPACE\tRUN\tSTATUS\tAGE\tCONFIRM\tMESSAGE")
+	for _, row := range rows {
+		confirm := "no"
+		if row.Run.Actions != nil && row.Run.Actions.IsConfirmable {
+			confirm = "yes"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", row.Workspace, row.Run.ID, row.Run.Status, FormatAge(now.Sub(row.Run.CreatedAt)), confirm, truncateMessage(row.Run.Message, 50))
+	}
+	w.Flush()
+
+	return out.String()
+}
+
+func truncateMessage(message string, size int) string {
+	message = strings.Join(strings.Fields(message), " ")
+	if len([]rune(message)) <= size {
+		return message
+	}
+
+	return string([]rune(message)[:size-3]) + "..."
+}
+
+// IsRunPlanSettled returns true once the plan of a run with the given status has finished
+func IsRunPlanSettled(status tfe.RunStatus) bool {
+	return containsRunStatus(runPlanSettledStatuses, status)
+}
+
+// IsRunFailed returns true if a run with the given status ended without a usable plan
+func IsRunFailed(status tfe.RunStatus) bool {
+	return containsRunStatus(runFailedStatuses, status)
+}
+
+func containsRunStatus(statuses []tfe.RunStatus, status tfe.RunStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatPlanSummary returns a one-line summary of the changes of a plan, like "+3 ~1 -0"
+func FormatPlanSummary(plan *tfe.Plan) string {
+	return fmt.Sprintf("+%d ~%d -%d", plan.ResourceAdditions, plan.ResourceChanges, plan.ResourceDestructions)
+}
+
+// PlanHasChanges returns true if the plan adds, changes or destroys any resource
+func PlanHasChanges(plan *tfe.Plan) bool {
+	return plan.HasChanges || plan.ResourceAdditions > 0 || plan.ResourceChanges > 0 || plan.ResourceDestructions > 0
 }
 
 // GetRunCreateOptions return options based on the flags values
-func GetRunCreateOptions(cmd *cobra.Command) tfe.RunCreateOptions {
+func GetRunCreateOptions(cmd *cobra.Command) (tfe.RunCreateOptions, error) {
 	var options tfe.RunCreateOptions
 
 	// Specifies if this plan is a destroy plan, which will destroy all
 	// provisioned resources.
 	isDestroy, err := cmd.Flags().GetBool("is-destroy")
 	if err != nil {
-		logrus.Fatalf("unable to get flag is-destroy\n%v", err)
+		return options, fmt.Errorf("unable to get flag is-destroy\n%w", err)
 	}
 
 	options.IsDestroy = &isDestroy
@@ -67,7 +433,7 @@ func GetRunCreateOptions(cmd *cobra.Command) tfe.RunCreateOptions {
 	// Specifies the message to be associated with this run.
 	message, err := cmd.Flags().GetString("message")
 	if err != nil {
-		logrus.Fatalf("unable to get flag message\n%v", err)
+		return options, fmt.Errorf("unable to get flag message\n%w", err)
 	}
 	if message != "" {
 		options.Message = &message
@@ -75,92 +441,402 @@ func GetRunCreateOptions(cmd *cobra.Command) tfe.RunCreateOptions {
 
 	targetAddrs, err := cmd.Flags().GetStringArray("target-addrs")
 	if err != nil {
-		logrus.Fatalf("unable to get flag target-addrs\n%v", err)
+		return options, fmt.Errorf("unable to get flag target-addrs\n%w", err)
 	}
 	if len(targetAddrs) > 0 {
 		options.TargetAddrs = targetAddrs
 	}
 
-	return options
+	// the following options are only sent when given, so the workspace settings apply otherwise
+	for flag, option := range map[string]**bool{
+		"refresh-only":      &options.RefreshOnly,
+		"refresh":           &options.Refresh,
+		"plan-only":         &options.PlanOnly,
+		"allow-empty-apply": &options.AllowEmptyApply,
+		"auto-apply":        &options.AutoApply,
+	} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetBool(flag)
+		if err != nil {
+			return options, fmt.Errorf("unable to get flag %s\n%w", flag, err)
+		}
+		*option = &value
+	}
+
+	replaceAddrs, err := cmd.Flags().GetStringArray("replace-addrs")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag replace-addrs\n%w", err)
+	}
+	if len(replaceAddrs) > 0 {
+		options.ReplaceAddrs = replaceAddrs
+	}
+
+	terraformVersion, err := cmd.Flags().GetString("terraform-version")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag terraform-version\n%w", err)
+	}
+	if terraformVersion != "" {
+		options.TerraformVersion = &terraformVersion
+	}
+
+	vars, err := cmd.Flags().GetStringArray("var")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag var\n%w", err)
+	}
+	options.Variables, err = ParseRunVariables(vars)
+	if err != nil {
+		return options, err
+	}
+
+	return options, nil
+}
+
+// ParseRunVariables converts key=value pairs into run variables. The API expects HCL values,
+// so a value is quoted unless it is already a string, list, object, number or bool literal.
+func ParseRunVariables(vars []string) ([]*tfe.RunVariable, error) {
+	var variables []*tfe.RunVariable
+	seen := make(map[string]bool)
+	for _, v := range vars {
+		key, value, found := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --var %q, expected key=value", v)
+		}
+
+		if seen[key] {
+			return nil, fmt.Errorf("invalid --var %q, %s is given more than once", v, key)
+		}
+		seen[key] = true
+
+		variables = append(variables, &tfe.RunVariable{Key: key, Value: hclValue(value)})
+	}
+
+	return variables, nil
+}
+
+// hclNumberRegexp matches the decimal numbers of HCL, unlike strconv.ParseFloat it refuses inf, NaN, hex and underscores
+var hclNumberRegexp = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+func hclValue(value string) string {
+	if value == "true" || value == "false" || strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+		return value
+	}
+
+	if hclNumberRegexp.MatchString(value) {
+		return value
+	}
+
+	return strconv.Quote(value)
+}
+
+// CheckRunCreateOptions returns an error if the options combine settings Terraform doesn't accept together
+func CheckRunCreateOptions(options tfe.RunCreateOptions) error {
+	isSet := func(b *bool) bool { return b != nil && *b }
+
+	switch {
+	case isSet(options.RefreshOnly) && options.Refresh != nil && !*options.Refresh:
+		return fmt.Errorf("--refresh-only can't be used with --refresh=false")
+	case isSet(options.RefreshOnly) && isSet(options.IsDestroy):
+		return fmt.Errorf("--refresh-only can't be used with --is-destroy")
+	case isSet(options.RefreshOnly) && len(options.ReplaceAddrs) > 0:
+		return fmt.Errorf("--refresh-only can't be used with --replace-addrs")
+	case isSet(options.IsDestroy) && len(options.ReplaceAddrs) > 0:
+		return fmt.Errorf("--replace-addrs can't be used with --is-destroy")
+	case isSet(options.PlanOnly) && isSet(options.AutoApply):
+		return fmt.Errorf("--plan-only can't be used with --auto-apply, a plan-only run can't be applied")
+	case isSet(options.PlanOnly) && isSet(options.AllowEmptyApply):
+		return fmt.Errorf("--plan-only can't be used with --allow-empty-apply, a plan-only run can't be applied")
+	case options.TerraformVersion != nil && !isSet(options.PlanOnly):
+		return fmt.Errorf("--terraform-version requires --plan-only")
+	}
+
+	return nil
+}
+
+// GetRunListOptions return options based on the flags values
+func GetRunListOptions(cmd *cobra.Command) (tfe.RunListOptions, error) {
+	var options tfe.RunListOptions
+
+	statuses, err := cmd.Flags().GetStringSlice("status")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag status\n%w", err)
+	}
+	options.Status = strings.ToLower(strings.Join(statuses, ","))
+
+	operations, err := cmd.Flags().GetStringSlice("operation")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag operation\n%w", err)
+	}
+	for i, operation := range operations {
+		operations[i] = strings.ReplaceAll(strings.ToLower(operation), "-", "_")
+	}
+	options.Operation = strings.Join(operations, ",")
+
+	sourceFlags, err := cmd.Flags().GetStringSlice("source")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag source\n%w", err)
+	}
+	var sources []string
+	for _, source := range sourceFlags {
+		aliases, ok := runSourceAliases[strings.ToLower(source)]
+		if !ok {
+			return options, fmt.Errorf("invalid --source %q, valid values are: ui, api, vcs, cli", source)
+		}
+		for _, alias := range aliases {
+			sources = append(sources, string(alias))
+		}
+	}
+	options.Source = strings.Join(sources, ",")
+
+	commit, err := cmd.Flags().GetString("commit")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag commit\n%w", err)
+	}
+	options.Commit = commit
+
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag user\n%w", err)
+	}
+	options.User = user
+
+	return options, nil
+}
+
+// GetRunListFilter return the filters of run list the API doesn't support, based on the flags values
+func GetRunListFilter(cmd *cobra.Command, now time.Time) (RunListFilter, error) {
+	var filter RunListFilter
+
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return filter, fmt.Errorf("unable to get flag since\n%w", err)
+	}
+	if filter.Since, err = ParseSince(since, now); err != nil {
+		return filter, fmt.Errorf("invalid --since\n%w", err)
+	}
+
+	until, err := cmd.Flags().GetString("until")
+	if err != nil {
+		return filter, fmt.Errorf("unable to get flag until\n%w", err)
+	}
+	if filter.Until, err = ParseSince(until, now); err != nil {
+		return filter, fmt.Errorf("invalid --until\n%w", err)
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Until.Before(filter.Since) {
+		return filter, fmt.Errorf("invalid --until, it must be after --since")
+	}
+
+	filter.Sort, err = cmd.Flags().GetString("sort")
+	if err != nil {
+		return filter, fmt.Errorf("unable to get flag sort\n%w", err)
+	}
+	if !helper.ContainsString(RunListSorts, filter.Sort) {
+		return filter, fmt.Errorf("invalid --sort %q, valid values are: %s", filter.Sort, strings.Join(RunListSorts, ", "))
+	}
+
+	filter.Limit, err = cmd.Flags().GetInt("limit")
+	if err != nil {
+		return filter, fmt.Errorf("unable to get flag limit\n%w", err)
+	}
+	if filter.Limit < 0 {
+		return filter, fmt.Errorf("invalid --limit %d, it can't be negative", filter.Limit)
+	}
+
+	return filter, nil
+}
+
+// InRunListWindow returns true if the run was created between --since and --until
+func (f RunListFilter) InRunListWindow(run *tfe.Run) bool {
+	if !f.Since.IsZero() && run.CreatedAt.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && run.CreatedAt.After(f.Until) {
+		return false
+	}
+
+	return true
+}
+
+// IsFull returns true once the given number of runs reaches --limit
+func (f RunListFilter) IsFull(count int) bool {
+	return f.Limit > 0 && count >= f.Limit
+}
+
+// SortRuns sorts the runs by creation date, newest first, or by status then creation date
+func SortRuns(runs []*tfe.Run, sortBy string) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		if sortBy == "status" && runs[i].Status != runs[j].Status {
+			return runs[i].Status < runs[j].Status
+		}
+		return runs[i].CreatedAt.After(runs[j].CreatedAt)
+	})
 }
 
 // GetRunReadOptions return options based on the command's flags value
-func GetRunReadOptions(cmd *cobra.Command) tfe.RunReadOptions {
+func GetRunReadOptions(cmd *cobra.Command) (tfe.RunReadOptions, error) {
 	var options tfe.RunReadOptions
 	include, err := cmd.Flags().GetString("include")
 	if err != nil {
-		logrus.Fatalf("unable to get flag include\n%v", err)
+		return options, fmt.Errorf("unable to get flag include\n%w", err)
 	}
 
 	if include != "" {
 		options.Include = []tfe.RunIncludeOpt{tfe.RunIncludeOpt(include)}
 	}
 
-	return options
+	return options, nil
 }
 
 // GetRunApplyOptions return options based on the command's flags value
-func GetRunApplyOptions(cmd *cobra.Command) tfe.RunApplyOptions {
+func GetRunApplyOptions(cmd *cobra.Command) (tfe.RunApplyOptions, error) {
 	var options tfe.RunApplyOptions
 
 	comment, err := cmd.Flags().GetString("comment")
 	if err != nil {
-		logrus.Fatalf("unable to get flag comment\n%v", err)
+		return options, fmt.Errorf("unable to get flag comment\n%w", err)
 	}
 
 	if comment != "" {
 		options.Comment = &comment
 	}
 
-	return options
+	return options, nil
+}
+
+// ApplyPreconditions are the checks a run must pass before it's applied
+type ApplyPreconditions struct {
+	IfNoDestroy       bool
+	MaxChanges        int
+	DenyAddrs         []string
+	ExpectedCommitSHA string
+	// MaxDelta is the maximum increase of the monthly cost, nil when not set
+	MaxDelta *float64
+}
+
+// IsEmpty returns true if no precondition is set
+func (p ApplyPreconditions) IsEmpty() bool {
+	return !p.IfNoDestroy && p.MaxChanges < 0 && len(p.DenyAddrs) == 0 && p.ExpectedCommitSHA == "" && p.MaxDelta == nil
+}
+
+// GetRunApplyPreconditions return the apply preconditions based on the command's flags value
+func GetRunApplyPreconditions(cmd *cobra.Command) (ApplyPreconditions, error) {
+	var preconditions ApplyPreconditions
+
+	ifNoDestroy, err := cmd.Flags().GetBool("if-no-destroy")
+	if err != nil {
+		return preconditions, fmt.Errorf("unable to get flag if-no-destroy\n%w", err)
+	}
+	preconditions.IfNoDestroy = ifNoDestroy
+
+	maxChanges, err := cmd.Flags().GetInt("max-changes")
+	if err != nil {
+		return preconditions, fmt.Errorf("unable to get flag max-changes\n%w", err)
+	}
+	preconditions.MaxChanges = maxChanges
+
+	denyAddrs, err := cmd.Flags().GetStringArray("deny-addrs")
+	if err != nil {
+		return preconditions, fmt.Errorf("unable to get flag deny-addrs\n%w", err)
+	}
+	preconditions.DenyAddrs = denyAddrs
+
+	expectedCommitSHA, err := cmd.Flags().GetString("expected-commit-sha")
+	if err != nil {
+		return preconditions, fmt.Errorf("unable to get flag expected-commit-sha\n%w", err)
+	}
+	preconditions.ExpectedCommitSHA = expectedCommitSHA
+
+	if cmd.Flags().Changed("max-delta") {
+		maxDelta, err := cmd.Flags().GetFloat64("max-delta")
+		if err != nil {
+			return preconditions, fmt.Errorf("unable to get flag max-delta\n%w", err)
+		}
+		preconditions.MaxDelta = &maxDelta
+	}
+
+	return preconditions, nil
+}
+
+// CheckPlanPreconditions returns the reasons why the plan doesn't meet the preconditions.
+// planJSON is only needed when DenyAddrs is set.
+func CheckPlanPreconditions(preconditions ApplyPreconditions, plan *tfe.Plan, planJSON *model.PlanJSON) []string {
+	var violations []string
+
+	if preconditions.IfNoDestroy && plan.ResourceDestructions > 0 {
+		violations = append(violations, fmt.Sprintf("the plan destroys %d resource(s)", plan.ResourceDestructions))
+	}
+
+	changes := plan.ResourceAdditions + plan.ResourceChanges + plan.ResourceDestructions
+	if preconditions.MaxChanges >= 0 && changes > preconditions.MaxChanges {
+		violations = append(violations, fmt.Sprintf("the plan changes %d resource(s), more than the maximum of %d", changes, preconditions.MaxChanges))
+	}
+
+	if len(preconditions.DenyAddrs) > 0 && planJSON != nil {
+		for _, change := range planJSON.ResourceChanges {
+			if IsNoopChange(change) {
+				continue
+			}
+
+			if pattern, ok := MatchAddressPattern(preconditions.DenyAddrs, change.Address); ok {
+				violations = append(violations, fmt.Sprintf("the plan changes %s (%s), which matches %s", change.Address, strings.Join(change.Change.Actions, ", "), pattern))
+			}
+		}
+	}
+
+	return violations
 }
 
 // GetRunCancelOptions return options based on the command's flags value
-func GetRunCancelOptions(cmd *cobra.Command) tfe.RunCancelOptions {
+func GetRunCancelOptions(cmd *cobra.Command) (tfe.RunCancelOptions, error) {
 	var options tfe.RunCancelOptions
 
 	comment, err := cmd.Flags().GetString("comment")
 	if err != nil {
-		logrus.Fatalf("unable to get flag comment\n%v", err)
+		return options, fmt.Errorf("unable to get flag comment\n%w", err)
 	}
 
 	if comment != "" {
 		options.Comment = &comment
 	}
 
-	return options
+	return options, nil
 }
 
 // GetRunForceCancelOptions return options based on the command's flags value
-func GetRunForceCancelOptions(cmd *cobra.Command) tfe.RunForceCancelOptions {
+func GetRunForceCancelOptions(cmd *cobra.Command) (tfe.RunForceCancelOptions, error) {
 	var options tfe.RunForceCancelOptions
 
 	comment, err := cmd.Flags().GetString("comment")
 	if err != nil {
-		logrus.Fatalf("unable to get flag comment\n%v", err)
+		return options, fmt.Errorf("unable to get flag comment\n%w", err)
 	}
 
 	if comment != "" {
 		options.Comment = &comment
 	}
 
-	return options
+	return options, nil
 }
 
 // GetRunDiscardOptions return options based on the command's flags value
-func GetRunDiscardOptions(cmd *cobra.Command) tfe.RunDiscardOptions {
+func GetRunDiscardOptions(cmd *cobra.Command) (tfe.RunDiscardOptions, error) {
 	var options tfe.RunDiscardOptions
 
 	comment, err := cmd.Flags().GetString("comment")
 	if err != nil {
-		logrus.Fatalf("unable to get flag comment\n%v", err)
+		return options, fmt.Errorf("unable to get flag comment\n%w", err)
 	}
 
 	if comment != "" {
 		options.Comment = &comment
 	}
 
-	return options
+	return options, nil
 }
 
 // PrintRunList TODO ...
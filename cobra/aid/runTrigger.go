@@ -0,0 +1,314 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/spf13/cobra"
+)
+
+// RunTriggerDirections are the valid values of --direction
+var RunTriggerDirections = []string{"all", "inbound", "outbound"}
+
+// RunTriggerListFormats are the output formats of run-trigger list
+var RunTriggerListFormats = []string{"table", "json"}
+
+// WorkspaceGraphFormats are the output formats of workspace graph
+var WorkspaceGraphFormats = []string{"tree", "dot", "mermaid"}
+
+// SetRunTriggerFlags define flags for the cobra command
+func SetRunTriggerFlags(cmd *cobra.Command) {
+	usage := `The run trigger ID. Used with delete, instead of --workspace and --source.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The name of the workspace where the triggered runs are queued. Required by list and create.`
+	cmd.Flags().String("workspace", "", usage)
+
+	usage = `Used with create and delete. The name of the workspace whose applies trigger the runs.`
+	cmd.Flags().String("source", "", usage)
+
+	usage = `Used with list. inbound lists the run triggers that queue runs in --workspace, outbound the ones that its applies fire, all both.`
+	cmd.Flags().String("direction", "all", usage)
+
+	usage = `Used with list. Output format: table or json.`
+	cmd.Flags().String("format", "table", usage)
+}
+
+// RenderRunTriggers renders the run triggers as a table
+func RenderRunTriggers(entries []model.RunTriggerEntry) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDIRECTION\tSOURCE\tWORKSPACE\tCREATED")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.ID, e.Direction, e.Source, e.Workspace, e.CreatedAt)
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+// NewRunTriggerGraph returns an empty run trigger graph
+func NewRunTriggerGraph() model.RunTriggerGraph {
+	return model.RunTriggerGraph{Triggers: make(map[string][]string)}
+}
+
+// AddRunTrigger adds the run trigger from source to workspace to the graph, keeping it sorted
+func AddRunTrigger(graph *model.RunTriggerGraph, source string, workspace string) {
+	for _, name := range []string{source, workspace} {
+		i := sort.SearchStrings(graph.Workspaces, name)
+		if i == len(graph.Workspaces) || graph.Workspaces[i] != name {
+			graph.Workspaces = append(graph.Workspaces, "")
+			copy(graph.Workspaces[i+1:], graph.Workspaces[i:])
+			graph.Workspaces[i] = name
+		}
+	}
+
+	targets := graph.Triggers[source]
+	i := sort.SearchStrings(targets, workspace)
+	if i < len(targets) && targets[i] == workspace {
+		return
+	}
+	targets = append(targets, "")
+	copy(targets[i+1:], targets[i:])
+	targets[i] = workspace
+	graph.Triggers[source] = targets
+}
+
+// FindRunTriggerCycles returns the cycles of the graph, each as the path of the workspaces it goes through,
+// e.g. [a b a]. Every workspace of a cycle can trigger itself through the others.
+func FindRunTriggerCycles(graph model.RunTriggerGraph) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int)
+	var path []string
+	var cycles [][]string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		path = append(path, name)
+
+		for _, next := range graph.Triggers[name] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				// a back edge closes the cycle going from next to name on the current path
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i] == next {
+						cycle := append(append([]string{}, path[i:]...), next)
+						cycles = append(cycles, cycle)
+						break
+					}
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+	}
+
+	for _, name := range graph.Workspaces {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+
+	return cycles
+}
+
+// GetTriggeredWorkspaces returns the workspaces whose runs are triggered, directly or not, by applying
+// the given workspace, nearest first
+func GetTriggeredWorkspaces(graph model.RunTriggerGraph, name string) []string {
+	seen := map[string]bool{name: true}
+	var triggered []string
+
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range graph.Triggers[current] {
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			triggered = append(triggered, next)
+			queue = append(queue, next)
+		}
+	}
+
+	return triggered
+}
+
+// GetDownstreamRunTriggerGraph returns the part of the graph reachable from the given workspace
+func GetDownstreamRunTriggerGraph(graph model.RunTriggerGraph, name string) model.RunTriggerGraph {
+	downstream := NewRunTriggerGraph()
+	included := map[string]bool{name: true}
+	for _, triggered := range GetTriggeredWorkspaces(graph, name) {
+		included[triggered] = true
+	}
+
+	for _, source := range graph.Workspaces {
+		if !included[source] {
+			continue
+		}
+		for _, workspace := range graph.Triggers[source] {
+			AddRunTrigger(&downstream, source, workspace)
+		}
+	}
+
+	return downstream
+}
+
+// RenderRunTriggerGraph renders the graph as a text tree, a Graphviz DOT digraph or a Mermaid flowchart.
+// The run triggers that close a cycle are marked.
+func RenderRunTriggerGraph(graph model.RunTriggerGraph, format string) (string, error) {
+	cycleEdges := make(map[[2]string]bool)
+	for _, cycle := range FindRunTriggerCycles(graph) {
+		cycleEdges[[2]string{cycle[len(cycle)-2], cycle[len(cycle)-1]}] = true
+	}
+
+	switch format {
+	case "tree":
+		return renderRunTriggerTree(graph), nil
+	case "dot":
+		return renderRunTriggerDOT(graph, cycleEdges), nil
+	case "mermaid":
+		return renderRunTriggerMermaid(graph, cycleEdges), nil
+	default:
+		return "", fmt.Errorf("unsupported format %s", format)
+	}
+}
+
+// renderRunTriggerTree renders a tree per workspace that no other workspace triggers. A workspace
+// already printed isn't expanded again, and a workspace that would trigger itself is marked as a cycle.
+func renderRunTriggerTree(graph model.RunTriggerGraph) string {
+	triggered := make(map[string]bool)
+	for _, targets := range graph.Triggers {
+		for _, target := range targets {
+			triggered[target] = true
+		}
+	}
+
+	var b strings.Builder
+	expanded := make(map[string]bool)
+	onPath := make(map[string]bool)
+
+	var walk func(name string, prefix string, last bool, root bool)
+	walk = func(name string, prefix string, last bool, root bool) {
+		line, childPrefix := name, ""
+		if !root {
+			branch, indent := "├── ", "│   "
+			if last {
+				branch, indent = "└── ", "    "
+			}
+			line, childPrefix = prefix+branch+name, prefix+indent
+		}
+
+		switch {
+		case onPath[name]:
+			fmt.Fprintf(&b, "%s (cycle)\n", line)
+			return
+		case expanded[name] && len(graph.Triggers[name]) > 0:
+			fmt.Fprintf(&b, "%s (see above)\n", line)
+			return
+		}
+		fmt.Fprintln(&b, line)
+
+		expanded[name] = true
+		onPath[name] = true
+		targets := graph.Triggers[name]
+		for i, target := range targets {
+			walk(target, childPrefix, i == len(targets)-1, false)
+		}
+		onPath[name] = false
+	}
+
+	for _, name := range graph.Workspaces {
+		if !triggered[name] {
+			walk(name, "", true, true)
+		}
+	}
+
+	// the workspaces of a cycle that no other workspace triggers have no root
+	for _, name := range graph.Workspaces {
+		if !expanded[name] {
+			walk(name, "", true, true)
+		}
+	}
+
+	return b.String()
+}
+
+func renderRunTriggerDOT(graph model.RunTriggerGraph, cycleEdges map[[2]string]bool) string {
+	var b strings.Builder
+	b.WriteString("digraph run_triggers {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, name := range graph.Workspaces {
+		fmt.Fprintf(&b, "  %q;\n", name)
+	}
+	for _, source := range graph.Workspaces {
+		for _, target := range graph.Triggers[source] {
+			attributes := ""
+			if cycleEdges[[2]string{source, target}] {
+				attributes = ` [color=red, label="cycle"]`
+			}
+			fmt.Fprintf(&b, "  %q -> %q%s;\n", source, target, attributes)
+		}
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func renderRunTriggerMermaid(graph model.RunTriggerGraph, cycleEdges map[[2]string]bool) string {
+	ids := make(map[string]string)
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for i, name := range graph.Workspaces {
+		ids[name] = fmt.Sprintf("w%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[name], strings.ReplaceAll(name, `"`, "#quot;"))
+	}
+
+	edge := 0
+	var cycleLinks []string
+	for _, source := range graph.Workspaces {
+		for _, target := range graph.Triggers[source] {
+			if cycleEdges[[2]string{source, target}] {
+				fmt.Fprintf(&b, "  %s -->|cycle| %s\n", ids[source], ids[target])
+				cycleLinks = append(cycleLinks, fmt.Sprint(edge))
+			} else {
+				fmt.Fprintf(&b, "  %s --> %s\n", ids[source], ids[target])
+			}
+			edge++
+		}
+	}
+
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:red\n", strings.Join(cycleLinks, ","))
+	}
+
+	return b.String()
+}
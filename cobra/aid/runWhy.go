@@ -0,0 +1,191 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// RunDiagnosisInput is what run why knows about a run and its workspace
+type RunDiagnosisInput struct {
+	Run       *tfe.Run
+	Workspace *tfe.Workspace
+	// LockingRun is the run holding the workspace lock, if it isn't the diagnosed run
+	LockingRun *tfe.Run
+	// CurrentRun is the current run of the workspace, if it isn't the diagnosed run
+	CurrentRun *tfe.Run
+	// QueuedAhead are the active runs created before the diagnosed run
+	QueuedAhead []*tfe.Run
+	// AgentPool is nil unless the workspace runs on agents and the agents could be listed
+	AgentPool *AgentPoolAvailability
+}
+
+// AgentPoolAvailability counts the agents of a pool by status
+type AgentPoolAvailability struct {
+	ID    string
+	Idle  int
+	Busy  int
+	Total int
+}
+
+// RunDiagnosis explains why a run doesn't progress and how to unblock it
+type RunDiagnosis struct {
+	Summary  string
+	Reasons  []string
+	Commands []string
+}
+
+// DiagnoseRun explains why a run is pending or waiting
+func DiagnoseRun(in RunDiagnosisInput, now time.Time) RunDiagnosis {
+	run, workspace := in.Run, in.Workspace
+	diagnosis := RunDiagnosis{Summary: fmt.Sprintf("run %s is %s on workspace %s", run.ID, run.Status, workspace.Name)}
+
+	if run.Status != tfe.RunPending {
+		diagnoseStartedRun(&diagnosis, run)
+		return diagnosis
+	}
+
+	lockingRunID := ""
+	if workspace.Locked && workspace.LockedBy != nil {
+		lockedBy := workspace.LockedBy
+		switch {
+		case lockedBy.Run != nil && lockedBy.Run.ID != run.ID:
+			locking := in.LockingRun
+			if locking == nil {
+				locking = lockedBy.Run
+			}
+			lockingRunID = locking.ID
+			diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("the workspace is locked by %s", describeRun(locking, now)))
+			diagnosis.Commands = append(diagnosis.Commands, unblockRunCommands(locking)...)
+		case lockedBy.User != nil:
+			diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("the workspace is locked by user %s", nameOrID(lockedBy.User.Username, lockedBy.User.ID)))
+			diagnosis.Commands = append(diagnosis.Commands, fmt.Sprintf("tecli workspace unlock --id %s", workspace.ID), fmt.Sprintf("tecli workspace force-unlock --id %s", workspace.ID))
+		case lockedBy.Team != nil:
+			diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("the workspace is locked by team %s", nameOrID(lockedBy.Team.Name, lockedBy.Team.ID)))
+			diagnosis.Commands = append(diagnosis.Commands, fmt.Sprintf("tecli workspace unlock --id %s", workspace.ID), fmt.Sprintf("tecli workspace force-unlock --id %s", workspace.ID))
+		}
+	}
+
+	if current := in.CurrentRun; current != nil && IsRunActive(current.Status) && current.ID != lockingRunID {
+		diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("blocked by %s", describeRun(current, now)))
+		diagnosis.Commands = append(diagnosis.Commands, unblockRunCommands(current)...)
+	}
+
+	if len(in.QueuedAhead) > 0 {
+		var ids []string
+		for _, queued := range in.QueuedAhead {
+			ids = append(ids, queued.ID)
+			diagnosis.Commands = append(diagnosis.Commands, fmt.Sprintf("tecli run discard --id %s", queued.ID))
+		}
+		diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("%d run(s) queued ahead of it: %s", len(ids), strings.Join(ids, ", ")))
+	}
+
+	if pool := in.AgentPool; pool != nil && pool.Idle == 0 {
+		diagnosis.Reasons = append(diagnosis.Reasons, fmt.Sprintf("no idle agent in agent pool %s (%d busy, %d in total)", pool.ID, pool.Busy, pool.Total))
+	}
+
+	if len(diagnosis.Reasons) == 0 {
+		diagnosis.Reasons = append(diagnosis.Reasons, "nothing on the workspace blocks it, it's probably waiting for a free slot of the organization's run concurrency")
+		diagnosis.Commands = append(diagnosis.Commands, "tecli run ps")
+	}
+
+	return diagnosis
+}
+
+// diagnoseStartedRun explains a run that already left the queue
+func diagnoseStartedRun(diagnosis *RunDiagnosis, run *tfe.Run) {
+	switch {
+	case !IsRunActive(run.Status):
+		diagnosis.Reasons = append(diagnosis.Reasons, "the run is finished, nothing blocks it")
+	case run.Actions != nil && run.Actions.IsConfirmable:
+		diagnosis.Reasons = append(diagnosis.Reasons, "the run is waiting for someone to confirm the apply")
+		diagnosis.Commands = append(diagnosis.Commands, unblockRunCommands(run)...)
+	case run.Status == tfe.RunPolicySoftFailed:
+		diagnosis.Reasons = append(diagnosis.Reasons, "the run failed a soft-mandatory policy and needs an override")
+		diagnosis.Commands = append(diagnosis.Commands, unblockRunCommands(run)...)
+	case run.Status == tfe.RunPostPlanAwaitingDecision:
+		diagnosis.Reasons = append(diagnosis.Reasons, "the run is waiting for a decision on a run task")
+		diagnosis.Commands = append(diagnosis.Commands, unblockRunCommands(run)...)
+	default:
+		diagnosis.Reasons = append(diagnosis.Reasons, "the run is in progress, nothing blocks it")
+	}
+}
+
+// describeRun returns a short description of a run, like "run-abc (applying, started 14m ago)"
+func describeRun(run *tfe.Run, now time.Time) string {
+	if run.Status == "" {
+		return run.ID
+	}
+
+	if run.CreatedAt.IsZero() {
+		return fmt.Sprintf("%s (%s)", run.ID, run.Status)
+	}
+
+	return fmt.Sprintf("%s (%s, started %s ago)", run.ID, run.Status, FormatAge(now.Sub(run.CreatedAt)))
+}
+
+// unblockRunCommands returns the commands that would move a run out of the way
+func unblockRunCommands(run *tfe.Run) []string {
+	if run.Actions == nil {
+		return []string{fmt.Sprintf("tecli run cancel --id %s", run.ID)}
+	}
+
+	var commands []string
+	if run.Actions.IsConfirmable {
+		commands = append(commands, fmt.Sprintf("tecli run apply --id %s", run.ID))
+	}
+
+	switch {
+	case run.Actions.IsDiscardable:
+		commands = append(commands, fmt.Sprintf("tecli run discard --id %s", run.ID))
+	case run.Actions.IsCancelable:
+		commands = append(commands, fmt.Sprintf("tecli run cancel --id %s", run.ID))
+	case run.Actions.IsForceCancelable:
+		commands = append(commands, fmt.Sprintf("tecli run force-cancel --id %s", run.ID))
+	}
+
+	return commands
+}
+
+func nameOrID(name string, id string) string {
+	if name != "" {
+		return name
+	}
+
+	return id
+}
+
+// FormatRunDiagnosis renders the diagnosis for the terminal
+func FormatRunDiagnosis(diagnosis RunDiagnosis) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s:\n", diagnosis.Summary)
+	for _, reason := range diagnosis.Reasons {
+		fmt.Fprintf(&out, "  - %s\n", reason)
+	}
+
+	if len(diagnosis.Commands) > 0 {
+		out.WriteString("\nTo unblock it:\n")
+		for _, command := range diagnosis.Commands {
+			fmt.Fprintf(&out, "  %s\n", command)
+		}
+	}
+
+	return out.String()
+}
@@ -19,16 +19,15 @@ import (
 	"fmt"
 
 	tfe "github.com/hashicorp/go-tfe"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 // GetSSHKeysCreateOptions return options based on the flags values
-func GetSSHKeysCreateOptions(cmd *cobra.Command) tfe.SSHKeyCreateOptions {
+func GetSSHKeysCreateOptions(cmd *cobra.Command) (tfe.SSHKeyCreateOptions, error) {
 	var options tfe.SSHKeyCreateOptions
 	name, err := cmd.Flags().GetString("name")
 	if err != nil {
-		logrus.Fatalf("unable to get flag name\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag name\n%w", err)
 	}
 
 	if name != "" {
@@ -37,14 +36,14 @@ func GetSSHKeysCreateOptions(cmd *cobra.Command) tfe.SSHKeyCreateOptions {
 
 	value, err := cmd.Flags().GetString("value")
 	if err != nil {
-		logrus.Fatalf("unable to get flag value\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag value\n%w", err)
 	}
 
 	if value != "" {
 		options.Value = &value
 	}
 
-	return options
+	return options, nil
 }
 
 // GetSSHKeyByName return SSHKey based on the given name
@@ -59,12 +58,12 @@ func GetSSHKeyByName(list *tfe.SSHKeyList, name string) tfe.SSHKey {
 }
 
 // GetSSHKeysUpdateOptions return options based on the flag values
-func GetSSHKeysUpdateOptions(cmd *cobra.Command) tfe.SSHKeyUpdateOptions {
+func GetSSHKeysUpdateOptions(cmd *cobra.Command) (tfe.SSHKeyUpdateOptions, error) {
 	var options tfe.SSHKeyUpdateOptions
 
 	name, err := cmd.Flags().GetString("name")
 	if err != nil {
-		logrus.Fatalf("unable to get flag name\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag name\n%w", err)
 	}
 
 	if name != "" {
@@ -76,10 +75,10 @@ func GetSSHKeysUpdateOptions(cmd *cobra.Command) tfe.SSHKeyUpdateOptions {
 	// The "value" flag is preserved on the command surface for backward compatibility
 	// but is silently ignored on updates.
 	if _, err := cmd.Flags().GetString("value"); err != nil {
-		logrus.Fatalf("unable to get flag value\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag value\n%w", err)
 	}
 
-	return options
+	return options, nil
 
 }
 
@@ -0,0 +1,236 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// StateListFormats are the output formats of state list
+var StateListFormats = []string{"table", "json"}
+
+// SetStateFlags define flags for the cobra command
+func SetStateFlags(cmd *cobra.Command) {
+	usage := `The State Version ID`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The name of the workspace. Used with list, current, and instead of --id with download and outputs.`
+	cmd.Flags().String("workspace", "", usage)
+
+	usage = `Used with download and --workspace. The serial of the state version to download, instead of the current one.`
+	cmd.Flags().Int64("serial", -1, usage)
+
+	usage = `Used with download. File the state is written to, instead of stdout.`
+	cmd.Flags().String("out", "", usage)
+
+	usage = `Used with download. Decompress the state if it was stored gzipped.`
+	cmd.Flags().Bool("decompress", false, usage)
+
+	usage = `Used with download. Indent the state JSON.`
+	cmd.Flags().Bool("pretty", false, usage)
+
+	usage = `Used with outputs. Print the value of the given output alone, without quotes. Only strings, numbers and bools are supported.`
+	cmd.Flags().String("raw", "", usage)
+
+	usage = `Used with push, the state file to upload. Used with migrate, the manifest that maps state files to workspaces.`
+	cmd.Flags().StringP("file", "f", "", usage)
+
+	usage = `Used with push and migrate. Upload the state even if its lineage differs or its serial is lower than the current state.`
+	cmd.Flags().Bool("force", false, usage)
+
+	usage = `Used with migrate. The file that records which states were pushed, so a stopped migration resumes where it stopped. Defaults to the manifest path followed by .progress.json.`
+	cmd.Flags().String("progress", "", usage)
+
+	usage = `Used with migrate. Only list the state files and the workspaces they would be pushed to.`
+	cmd.Flags().Bool("dry-run", false, usage)
+
+	usage = `Used with migrate. How long to wait for the uploaded state to be processed before its resources are counted.`
+	cmd.Flags().Duration("verify-timeout", 5*time.Minute, usage)
+
+	usage = `Used with list. Output format: table or json.`
+	cmd.Flags().String("format", "table", usage)
+
+	usage = `Used with list. Maximum number of state versions to list, the most recent ones. 0 lists every state version.`
+	cmd.Flags().Int("limit", 20, usage)
+}
+
+// ParseStateFile decodes a Terraform state file
+func ParseStateFile(data []byte) (*model.StateFile, error) {
+	var state model.StateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("unable to parse state\n%w", err)
+	}
+
+	if state.Version == 0 || state.Lineage == "" {
+		return nil, fmt.Errorf("invalid state, the version and lineage are required")
+	}
+
+	return &state, nil
+}
+
+// CountStateInstances returns the number of managed resource instances of a state, data sources aren't counted
+func CountStateInstances(state *model.StateFile) int {
+	count := 0
+	for _, r := range state.Resources {
+		if r.Mode == "managed" {
+			count += len(r.Instances)
+		}
+	}
+
+	return count
+}
+
+// CheckStatePush returns the reasons why the local state must not replace the current state of the workspace.
+// A state with the same lineage and a higher serial is always accepted. force accepts any state.
+func CheckStatePush(local *model.StateFile, current *model.StateFile, force bool) []string {
+	if current == nil || force {
+		return nil
+	}
+
+	var violations []string
+	if local.Lineage != current.Lineage {
+		violations = append(violations, fmt.Sprintf("the lineage %s of the state file doesn't match the lineage %s of the current state", local.Lineage, current.Lineage))
+	}
+
+	switch {
+	case local.Serial < current.Serial:
+		violations = append(violations, fmt.Sprintf("the serial %d of the state file is lower than the serial %d of the current state", local.Serial, current.Serial))
+	case local.Serial == current.Serial:
+		violations = append(violations, fmt.Sprintf("the state file has the same serial %d as the current state, but a different content", local.Serial))
+	}
+
+	return violations
+}
+
+// GetStateMD5 returns the hex encoded MD5 checksum of a state, as expected by the API
+func GetStateMD5(data []byte) string {
+	return fmt.Sprintf("%x", md5.Sum(data))
+}
+
+// GetStateVersionCreateOptions return the options to upload the given state file
+func GetStateVersionCreateOptions(state *model.StateFile, data []byte, force bool) tfe.StateVersionCreateOptions {
+	lineage := state.Lineage
+	serial := state.Serial
+	checksum := GetStateMD5(data)
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	options := tfe.StateVersionCreateOptions{
+		Lineage: &lineage,
+		MD5:     &checksum,
+		Serial:  &serial,
+		State:   &encoded,
+	}
+
+	if force {
+		options.Force = &force
+	}
+
+	return options
+}
+
+// DecodeStateDownload optionally gunzips and indents a downloaded state
+func DecodeStateDownload(data []byte, decompress bool, pretty bool) ([]byte, error) {
+	// gzip streams start with the magic bytes 1f 8b
+	if decompress && len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress state\n%w", err)
+		}
+		defer reader.Close()
+
+		data, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress state\n%w", err)
+		}
+	}
+
+	if pretty {
+		var out bytes.Buffer
+		if err := json.Indent(&out, data, "", "  "); err != nil {
+			return nil, fmt.Errorf("unable to indent state, it isn't JSON\n%w", err)
+		}
+		out.WriteString("\n")
+		data = out.Bytes()
+	}
+
+	return data, nil
+}
+
+// RenderStateVersions renders state versions as a table
+func RenderStateVersions(versions []*tfe.StateVersion) string {
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSERIAL\tCREATED\tSTATUS\tTERRAFORM")
+	for _, sv := range versions {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", sv.ID, sv.Serial, sv.CreatedAt.Format("2006-01-02 15:04"), sv.Status, sv.TerraformVersion)
+	}
+	w.Flush()
+
+	return out.String()
+}
+
+// GetStateOutputValues returns the values of the non-sensitive outputs by name, and the names of the sensitive ones
+func GetStateOutputValues(outputs []*tfe.StateVersionOutput) (map[string]interface{}, []string) {
+	values := make(map[string]interface{})
+	var sensitive []string
+	for _, o := range outputs {
+		if o.Sensitive {
+			sensitive = append(sensitive, o.Name)
+			continue
+		}
+		values[o.Name] = o.Value
+	}
+	sort.Strings(sensitive)
+
+	return values, sensitive
+}
+
+// FormatRawOutput formats an output value the way terraform output -raw does
+func FormatRawOutput(output *tfe.StateVersionOutput) (string, error) {
+	if output.Sensitive {
+		return "", fmt.Errorf("output %s is sensitive, its value isn't available", output.Name)
+	}
+
+	switch v := output.Value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case nil:
+		return "", fmt.Errorf("output %s is null", output.Name)
+	default:
+		return "", fmt.Errorf("unsupported value for output %s, --raw only supports strings, numbers and bools, use the JSON output instead", output.Name)
+	}
+}
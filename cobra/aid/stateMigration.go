@@ -0,0 +1,195 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/hashicorp/go-tfe"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Statuses of a state file in the progress file of a migration
+const (
+	StateMigrationDone   = "done"
+	StateMigrationFailed = "failed"
+)
+
+var workspaceNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ParseStateMigrationManifest decodes and checks a migration manifest
+func ParseStateMigrationManifest(data []byte) (*model.StateMigrationManifest, error) {
+	var manifest model.StateMigrationManifest
+	if err := yaml.UnmarshalStrict(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest\n%w", err)
+	}
+
+	if len(manifest.States) == 0 {
+		return nil, fmt.Errorf("invalid manifest, states are required")
+	}
+
+	for i, source := range manifest.States {
+		switch {
+		case (source.File == "") == (source.Dir == ""):
+			return nil, fmt.Errorf("invalid manifest, states[%d] requires either file or dir", i)
+		case source.File != "" && source.Workspace == "":
+			return nil, fmt.Errorf("invalid manifest, states[%d] requires a workspace for file %s", i, source.File)
+		case source.Dir != "" && source.Workspace != "":
+			return nil, fmt.Errorf("invalid manifest, states[%d] takes a workspace-prefix for dir %s, not a workspace", i, source.Dir)
+		}
+	}
+
+	return &manifest, nil
+}
+
+// ExpandStateMigration lists the state files of the manifest in order, with their workspace.
+// Relative paths are relative to baseDir. The *.tfstate files of a dir are listed in lexical
+// order, each pushed to the workspace-prefix followed by its directory, with / replaced by -.
+func ExpandStateMigration(manifest *model.StateMigrationManifest, baseDir string) ([]model.StateMigrationItem, error) {
+	var items []model.StateMigrationItem
+	for _, source := range manifest.States {
+		if source.File != "" {
+			items = append(items, model.StateMigrationItem{File: resolvePath(baseDir, source.File), Workspace: source.Workspace})
+			continue
+		}
+
+		dir := resolvePath(baseDir, source.Dir)
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".tfstate") {
+				return err
+			}
+
+			rel, err := filepath.Rel(dir, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+
+			name := strings.TrimRight(source.WorkspacePrefix, "-_")
+			if rel != "." {
+				name = source.WorkspacePrefix + strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")
+			}
+
+			items = append(items, model.StateMigrationItem{File: path, Workspace: name})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the state files of %s\n%w", dir, err)
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, item := range items {
+		if !workspaceNameRegexp.MatchString(item.Workspace) {
+			return nil, fmt.Errorf("invalid workspace name %q for %s, only letters, numbers, - and _ are allowed", item.Workspace, item.File)
+		}
+
+		if file, ok := seen[item.Workspace]; ok {
+			return nil, fmt.Errorf("invalid manifest, both %s and %s are pushed to workspace %s", file, item.File, item.Workspace)
+		}
+		seen[item.Workspace] = item.File
+	}
+
+	return items, nil
+}
+
+func resolvePath(baseDir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(baseDir, path)
+}
+
+// ReadStateMigrationProgress reads a progress file, a missing file is an empty progress
+func ReadStateMigrationProgress(path string) (*model.StateMigrationProgress, error) {
+	var progress model.StateMigrationProgress
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &progress, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read progress file %s\n%w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("unable to parse progress file %s\n%w", path, err)
+	}
+
+	return &progress, nil
+}
+
+// WriteStateMigrationProgress replaces the progress file, so an interrupted write never leaves it truncated
+func WriteStateMigrationProgress(path string, progress *model.StateMigrationProgress) error {
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode progress\n%w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("unable to write progress file %s\n%w", path, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("unable to write progress file %s\n%w", path, err)
+	}
+
+	return nil
+}
+
+// SetStateMigrationProgress records the outcome of a state file, replacing its previous one
+func SetStateMigrationProgress(progress *model.StateMigrationProgress, entry model.StateMigrationProgressEntry) {
+	for i, e := range progress.Entries {
+		if e.File == entry.File && e.Workspace == entry.Workspace {
+			progress.Entries[i] = entry
+			return
+		}
+	}
+
+	progress.Entries = append(progress.Entries, entry)
+}
+
+// IsStateMigrationDone returns true if the state file, with the same content, was already pushed to the workspace
+func IsStateMigrationDone(progress *model.StateMigrationProgress, item model.StateMigrationItem, md5 string) bool {
+	for _, e := range progress.Entries {
+		if e.File == item.File && e.Workspace == item.Workspace {
+			return e.Status == StateMigrationDone && e.MD5 == md5
+		}
+	}
+
+	return false
+}
+
+// CountStateVersionResources returns the number of managed resource instances of a processed state version
+func CountStateVersionResources(sv *tfe.StateVersion) int {
+	count := 0
+	for _, r := range sv.Resources {
+		if strings.HasPrefix(r.Type, "data.") || strings.HasPrefix(r.Name, "data.") {
+			continue
+		}
+		count += r.Count
+	}
+
+	return count
+}
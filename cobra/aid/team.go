@@ -0,0 +1,211 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"fmt"
+
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// TeamVisibilities are the valid values of --visibility
+var TeamVisibilities = []string{"secret", "organization"}
+
+// teamOrganizationAccessFlags maps the organization access flags to the setting they change
+var teamOrganizationAccessFlags = []struct {
+	name    string
+	usage   string
+	setting func(access *tfe.OrganizationAccessOptions, value *bool)
+}{
+	{"manage-policies", `Allow the team to create, edit and delete the Sentinel policies of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManagePolicies = v }},
+	{"manage-policy-overrides", `Allow the team to override soft-mandatory policy checks.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManagePolicyOverrides = v }},
+	{"manage-workspaces", `Allow the team to create and administrate every workspace of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageWorkspaces = v }},
+	{"manage-vcs-settings", `Allow the team to manage the VCS providers and SSH keys of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageVCSSettings = v }},
+	{"manage-providers", `Allow the team to publish and delete providers in the private registry.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageProviders = v }},
+	{"manage-modules", `Allow the team to publish and delete modules in the private registry.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageModules = v }},
+	{"manage-run-tasks", `Allow the team to manage the run tasks of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageRunTasks = v }},
+	{"manage-projects", `Allow the team to create and administrate every project of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageProjects = v }},
+	{"read-workspaces", `Allow the team to read every workspace of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ReadWorkspaces = v }},
+	{"read-projects", `Allow the team to read every project of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ReadProjects = v }},
+	{"manage-membership", `Allow the team to invite users to the organization and to manage the members of the teams.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageMembership = v }},
+	{"manage-teams", `Allow the team to create, update and delete the teams of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageTeams = v }},
+	{"manage-organization-access", `Allow the team to change the organization access of the teams.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageOrganizationAccess = v }},
+	{"access-secret-teams", `Allow the team to see and manage the secret teams.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.AccessSecretTeams = v }},
+	{"manage-agent-pools", `Allow the team to manage the agent pools of the organization.`, func(a *tfe.OrganizationAccessOptions, v *bool) { a.ManageAgentPools = v }},
+}
+
+// SetTeamFlags define flags for the cobra command
+func SetTeamFlags(cmd *cobra.Command) {
+	usage := `The team ID. Used with read, update, delete, add-member and remove-member, instead of --name.`
+	cmd.Flags().String("id", "", usage)
+
+	usage = `The team name. Required by create. Used with read, update, delete, add-member and remove-member, instead of --id.`
+	cmd.Flags().String("name", "", usage)
+
+	usage = `Used with list. A search string (partial team name) used to filter the results.`
+	cmd.Flags().String("search", "", usage)
+
+	// Create, Update
+	usage = `Used with update. A new name for the team.`
+	cmd.Flags().String("new-name", "", usage)
+
+	usage = `Used with create and update. The team visibility: secret (only visible to its members) or organization.`
+	cmd.Flags().String("visibility", "", usage)
+
+	usage = `Used with create and update. The unique identifier of the team in the SAML identity provider, which controls its membership.`
+	cmd.Flags().String("sso-team-id", "", usage)
+
+	usage = `Used with create and update. Whether the team members can manage the team token.`
+	cmd.Flags().Bool("allow-member-token-management", false, usage)
+
+	for _, flag := range teamOrganizationAccessFlags {
+		cmd.Flags().Bool(flag.name, false, "Used with create and update. "+flag.usage)
+	}
+
+	// AddMember, RemoveMember
+	usage = `Used with add-member and remove-member. The usernames of the users to add to or remove from the team. Can be repeated or comma separated.`
+	var emptyArray []string
+	cmd.Flags().StringSlice("username", emptyArray, usage)
+}
+
+// GetTeamOrganizationAccess returns the organization access given by the flags, nil if none is given.
+// Only the flags given change the access, e.g. --manage-workspaces=false revokes that access alone.
+func GetTeamOrganizationAccess(cmd *cobra.Command) (*tfe.OrganizationAccessOptions, error) {
+	var access *tfe.OrganizationAccessOptions
+	for _, flag := range teamOrganizationAccessFlags {
+		if !cmd.Flags().Changed(flag.name) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetBool(flag.name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get flag %s\n%w", flag.name, err)
+		}
+
+		if access == nil {
+			access = &tfe.OrganizationAccessOptions{}
+		}
+		flag.setting(access, &value)
+	}
+
+	return access, nil
+}
+
+// GetTeamCreateOptions return options based on the flags values
+func GetTeamCreateOptions(cmd *cobra.Command) (tfe.TeamCreateOptions, error) {
+	var options tfe.TeamCreateOptions
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+	options.Name = &name
+
+	visibility, ssoTeamID, allowMemberTokenManagement, err := getTeamSettings(cmd)
+	if err != nil {
+		return options, err
+	}
+	options.Visibility = visibility
+	options.SSOTeamID = ssoTeamID
+	options.AllowMemberTokenManagement = allowMemberTokenManagement
+
+	options.OrganizationAccess, err = GetTeamOrganizationAccess(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	return options, nil
+}
+
+// GetTeamUpdateOptions return options based on the flags values
+func GetTeamUpdateOptions(cmd *cobra.Command) (tfe.TeamUpdateOptions, error) {
+	var options tfe.TeamUpdateOptions
+
+	newName, err := cmd.Flags().GetString("new-name")
+	if err != nil {
+		return options, fmt.Errorf("unable to get flag new-name\n%w", err)
+	}
+	if newName != "" {
+		options.Name = &newName
+	}
+
+	visibility, ssoTeamID, allowMemberTokenManagement, err := getTeamSettings(cmd)
+	if err != nil {
+		return options, err
+	}
+	options.Visibility = visibility
+	options.SSOTeamID = ssoTeamID
+	options.AllowMemberTokenManagement = allowMemberTokenManagement
+
+	options.OrganizationAccess, err = GetTeamOrganizationAccess(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	return options, nil
+}
+
+// getTeamSettings returns the team settings shared by create and update, nil when not given
+func getTeamSettings(cmd *cobra.Command) (*string, *string, *bool, error) {
+	var visibility, ssoTeamID *string
+	var allowMemberTokenManagement *bool
+
+	if cmd.Flags().Changed("visibility") {
+		value, err := cmd.Flags().GetString("visibility")
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to get flag visibility\n%w", err)
+		}
+
+		if !helper.ContainsString(TeamVisibilities, value) {
+			return nil, nil, nil, fmt.Errorf("invalid --visibility %q, valid values are: secret, organization", value)
+		}
+		visibility = &value
+	}
+
+	if cmd.Flags().Changed("sso-team-id") {
+		value, err := cmd.Flags().GetString("sso-team-id")
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to get flag sso-team-id\n%w", err)
+		}
+		ssoTeamID = &value
+	}
+
+	if cmd.Flags().Changed("allow-member-token-management") {
+		value, err := cmd.Flags().GetBool("allow-member-token-management")
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to get flag allow-member-token-management\n%w", err)
+		}
+		allowMemberTokenManagement = &value
+	}
+
+	return visibility, ssoTeamID, allowMemberTokenManagement, nil
+}
+
+// GetTeamUsernames returns the usernames given by --username
+func GetTeamUsernames(cmd *cobra.Command) ([]string, error) {
+	usernames, err := cmd.Flags().GetStringSlice("username")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag username\n%w", err)
+	}
+
+	if len(usernames) == 0 {
+		return nil, fmt.Errorf("--username must be defined")
+	}
+
+	return usernames, nil
+}
@@ -0,0 +1,369 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"encoding/csv"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// TeamAccessLevels are the valid values of --access
+var TeamAccessLevels = []string{"read", "plan", "write", "admin", "custom"}
+
+// TeamAccessListFormats are the output formats of team-access list
+var TeamAccessListFormats = []string{"table", "json"}
+
+// TeamAccessMatrixFormats are the output formats of team-access matrix
+var TeamAccessMatrixFormats = []string{"table", "csv"}
+
+// teamAccessCustomFlags are the permissions of a custom access, with their valid values
+var teamAccessCustomFlags = map[string][]string{
+	"runs":           {"read", "plan", "apply"},
+	"variables":      {"none", "read", "write"},
+	"state-versions": {"none", "read-outputs", "read", "write"},
+	"sentinel-mocks": {"none", "read"},
+}
+
+// SetTeamAccessFlags define flags for the cobra command
+func SetTeamAccessFlags(cmd *cobra.Command) {
+	usage := `The team name. Required by add, update and remove, unless --team-id is given. Used with list to only list the accesses of this team.`
+	cmd.Flags().String("team", "", usage)
+
+	usage = `The team ID, instead of --team.`
+	cmd.Flags().String("team-id", "", usage)
+
+	usage = `A workspace name, or a glob pattern matching workspace names such as app-*. Can be repeated or comma separated. Required by add, update and remove. Used with list and matrix to only report these workspaces.`
+	var emptyArray []string
+	cmd.Flags().StringSlice("workspace", emptyArray, usage)
+
+	// Add, Update
+	usage = `Used with add and update. The access of the team: read, plan, write, admin or custom.`
+	cmd.Flags().String("access", "", usage)
+
+	usage = `Used with --access custom. The permission on runs: read, plan or apply.`
+	cmd.Flags().String("runs", "", usage)
+
+	usage = `Used with --access custom. The permission on variables: none, read or write.`
+	cmd.Flags().String("variables", "", usage)
+
+	usage = `Used with --access custom. The permission on state versions: none, read-outputs, read or write.`
+	cmd.Flags().String("state-versions", "", usage)
+
+	usage = `Used with --access custom. The permission on Sentinel mocks: none or read.`
+	cmd.Flags().String("sentinel-mocks", "", usage)
+
+	usage = `Used with --access custom. Whether the team can lock and unlock the workspace.`
+	cmd.Flags().Bool("workspace-locking", false, usage)
+
+	usage = `Used with --access custom. Whether the team can manage the run tasks of the workspace.`
+	cmd.Flags().Bool("run-tasks", false, usage)
+
+	// List, Matrix
+	usage = `Used with list and matrix. Output format: table or json for list, table or csv for matrix.`
+	cmd.Flags().String("format", "table", usage)
+
+	usage = `Used with list and matrix. The number of workspaces whose team accesses are fetched at the same time.`
+	cmd.Flags().Int("concurrency", 8, usage)
+}
+
+// teamAccessPermissions are the access and custom permissions given by the flags, nil when not given
+type teamAccessPermissions struct {
+	access           *tfe.AccessType
+	runs             *tfe.RunsPermissionType
+	variables        *tfe.VariablesPermissionType
+	stateVersions    *tfe.StateVersionsPermissionType
+	sentinelMocks    *tfe.SentinelMocksPermissionType
+	workspaceLocking *bool
+	runTasks         *bool
+}
+
+func getTeamAccessPermissions(cmd *cobra.Command) (teamAccessPermissions, error) {
+	var permissions teamAccessPermissions
+
+	access, err := cmd.Flags().GetString("access")
+	if err != nil {
+		return permissions, fmt.Errorf("unable to get flag access\n%w", err)
+	}
+
+	if access != "" {
+		if !helper.ContainsString(TeamAccessLevels, access) {
+			return permissions, fmt.Errorf("invalid --access %q, valid values are: %s", access, strings.Join(TeamAccessLevels, ", "))
+		}
+
+		accessType := tfe.AccessType(access)
+		permissions.access = &accessType
+	}
+
+	custom := map[string]string{}
+	for flag, values := range teamAccessCustomFlags {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return permissions, fmt.Errorf("unable to get flag %s\n%w", flag, err)
+		}
+
+		if !helper.ContainsString(values, value) {
+			return permissions, fmt.Errorf("invalid --%s %q, valid values are: %s", flag, value, strings.Join(values, ", "))
+		}
+		custom[flag] = value
+	}
+
+	for _, flag := range []string{"workspace-locking", "run-tasks"} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetBool(flag)
+		if err != nil {
+			return permissions, fmt.Errorf("unable to get flag %s\n%w", flag, err)
+		}
+
+		if flag == "workspace-locking" {
+			permissions.workspaceLocking = &value
+		} else {
+			permissions.runTasks = &value
+		}
+		custom[flag] = fmt.Sprint(value)
+	}
+
+	if len(custom) > 0 && access != string(tfe.AccessCustom) {
+		return permissions, fmt.Errorf("--runs, --variables, --state-versions, --sentinel-mocks, --workspace-locking and --run-tasks require --access custom")
+	}
+
+	if value, ok := custom["runs"]; ok {
+		runs := tfe.RunsPermissionType(value)
+		permissions.runs = &runs
+	}
+
+	if value, ok := custom["variables"]; ok {
+		variables := tfe.VariablesPermissionType(value)
+		permissions.variables = &variables
+	}
+
+	if value, ok := custom["state-versions"]; ok {
+		stateVersions := tfe.StateVersionsPermissionType(value)
+		permissions.stateVersions = &stateVersions
+	}
+
+	if value, ok := custom["sentinel-mocks"]; ok {
+		sentinelMocks := tfe.SentinelMocksPermissionType(value)
+		permissions.sentinelMocks = &sentinelMocks
+	}
+
+	return permissions, nil
+}
+
+// GetTeamAccessAddOptions return options based on the flags values, without the team and the workspace
+func GetTeamAccessAddOptions(cmd *cobra.Command) (tfe.TeamAccessAddOptions, error) {
+	var options tfe.TeamAccessAddOptions
+
+	permissions, err := getTeamAccessPermissions(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	if permissions.access == nil {
+		return options, fmt.Errorf("--access must be defined")
+	}
+
+	options.Access = permissions.access
+	options.Runs = permissions.runs
+	options.Variables = permissions.variables
+	options.StateVersions = permissions.stateVersions
+	options.SentinelMocks = permissions.sentinelMocks
+	options.WorkspaceLocking = permissions.workspaceLocking
+	options.RunTasks = permissions.runTasks
+
+	return options, nil
+}
+
+// GetTeamAccessUpdateOptions return options based on the flags values
+func GetTeamAccessUpdateOptions(cmd *cobra.Command) (tfe.TeamAccessUpdateOptions, error) {
+	var options tfe.TeamAccessUpdateOptions
+
+	permissions, err := getTeamAccessPermissions(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	if permissions.access == nil {
+		return options, fmt.Errorf("--access must be defined")
+	}
+
+	options.Access = permissions.access
+	options.Runs = permissions.runs
+	options.Variables = permissions.variables
+	options.StateVersions = permissions.stateVersions
+	options.SentinelMocks = permissions.sentinelMocks
+	options.WorkspaceLocking = permissions.workspaceLocking
+	options.RunTasks = permissions.runTasks
+
+	return options, nil
+}
+
+// IsWorkspacePattern returns true if the given --workspace value is a glob pattern rather than a name
+func IsWorkspacePattern(value string) bool {
+	return strings.ContainsAny(value, `*?[\`)
+}
+
+// MatchWorkspaceNames returns the names matching the given names and glob patterns, in the order of
+// the names. A name or a pattern that matches no workspace is an error.
+func MatchWorkspaceNames(names []string, patterns []string) ([]string, error) {
+	matched := make(map[string]bool)
+	for _, pattern := range patterns {
+		found := false
+		for _, name := range names {
+			ok, err := path.Match(pattern, name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --workspace pattern %q\n%v", pattern, err)
+			}
+
+			if ok {
+				matched[name] = true
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("no workspace matches %q\n%w", pattern, tfe.ErrResourceNotFound)
+		}
+	}
+
+	var result []string
+	for _, name := range names {
+		if matched[name] {
+			result = append(result, name)
+		}
+	}
+
+	return result, nil
+}
+
+// NewTeamAccessEntry returns the access of a team to a workspace with their names
+func NewTeamAccessEntry(workspace string, team string, ta *tfe.TeamAccess) model.TeamAccessEntry {
+	return model.TeamAccessEntry{
+		Workspace:        workspace,
+		Team:             team,
+		ID:               ta.ID,
+		Access:           string(ta.Access),
+		Runs:             string(ta.Runs),
+		Variables:        string(ta.Variables),
+		StateVersions:    string(ta.StateVersions),
+		SentinelMocks:    string(ta.SentinelMocks),
+		WorkspaceLocking: ta.WorkspaceLocking,
+		RunTasks:         ta.RunTasks,
+	}
+}
+
+// FormatTeamAccess formats an access for the matrix, a custom access listing its permissions
+func FormatTeamAccess(entry model.TeamAccessEntry) string {
+	if entry.Access != string(tfe.AccessCustom) {
+		return entry.Access
+	}
+
+	return fmt.Sprintf("custom(runs=%s variables=%s state-versions=%s sentinel-mocks=%s workspace-locking=%t run-tasks=%t)",
+		entry.Runs, entry.Variables, entry.StateVersions, entry.SentinelMocks, entry.WorkspaceLocking, entry.RunTasks)
+}
+
+// RenderTeamAccesses renders the team accesses as a table sorted by workspace and team
+func RenderTeamAccesses(entries []model.TeamAccessEntry) string {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Workspace != entries[j].Workspace {
+			return entries[i].Workspace < entries[j].Workspace
+		}
+		return entries[i].Team < entries[j].Team
+	})
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WORKSPACE\tTEAM\tACCESS\tRUNS\tVARIABLES\tSTATE VERSIONS\tSENTINEL MOCKS\tLOCKING\tRUN TASKS\tID")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%t\t%t\t%s\n", e.Workspace, e.Team, e.Access, e.Runs, e.Variables, e.StateVersions, e.SentinelMocks, e.WorkspaceLocking, e.RunTasks, e.ID)
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+// NewTeamAccessMatrix builds the matrix of the given team accesses, the teams and the workspaces being sorted
+func NewTeamAccessMatrix(entries []model.TeamAccessEntry, teams []string, workspaces []string) model.TeamAccessMatrix {
+	matrix := model.TeamAccessMatrix{
+		Teams:      append([]string(nil), teams...),
+		Workspaces: append([]string(nil), workspaces...),
+		Access:     make(map[string]map[string]string),
+	}
+	sort.Strings(matrix.Teams)
+	sort.Strings(matrix.Workspaces)
+
+	for _, e := range entries {
+		if matrix.Access[e.Workspace] == nil {
+			matrix.Access[e.Workspace] = make(map[string]string)
+		}
+		matrix.Access[e.Workspace][e.Team] = FormatTeamAccess(e)
+	}
+
+	return matrix
+}
+
+// RenderTeamAccessMatrix renders the matrix with a row per workspace and a column per team, as a table or CSV
+func RenderTeamAccessMatrix(matrix model.TeamAccessMatrix, format string) (string, error) {
+	var b strings.Builder
+
+	header := append([]string{"workspace"}, matrix.Teams...)
+	rows := [][]string{header}
+	for _, workspace := range matrix.Workspaces {
+		row := []string{workspace}
+		for _, team := range matrix.Teams {
+			row = append(row, matrix.Access[workspace][team])
+		}
+		rows = append(rows, row)
+	}
+
+	if format == "csv" {
+		w := csv.NewWriter(&b)
+		if err := w.WriteAll(rows); err != nil {
+			return "", fmt.Errorf("unable to write the matrix as CSV\n%w", err)
+		}
+
+		return b.String(), nil
+	}
+
+	rows[0][0] = "WORKSPACE"
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		for i, cell := range row {
+			if cell == "" {
+				row[i] = "-"
+			}
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	return b.String(), nil
+}
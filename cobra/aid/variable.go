@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	tfe "github.com/hashicorp/go-tfe"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -37,14 +36,14 @@ func SetVariableFlags(cmd *cobra.Command) {
 }
 
 // GetVariableCreateOptions return tfe.VariableCreateOptions with correpondent values given by the flags
-func GetVariableCreateOptions(cmd *cobra.Command) tfe.VariableCreateOptions {
+func GetVariableCreateOptions(cmd *cobra.Command) (tfe.VariableCreateOptions, error) {
 	var options tfe.VariableCreateOptions
 
 	if cmd.Flags().Changed("key") {
 		// The name of the variable.
 		key, err := cmd.Flags().GetString("key")
 		if err != nil {
-			logrus.Fatalf("unable to get flag key\n%v", err)
+			return options, fmt.Errorf("unable to get flag key\n%w", err)
 		}
 
 		options.Key = &key
@@ -54,7 +53,7 @@ func GetVariableCreateOptions(cmd *cobra.Command) tfe.VariableCreateOptions {
 		// The value of the variable.
 		value, err := cmd.Flags().GetString("value")
 		if err != nil {
-			logrus.Fatalf("unable to get flag value\n%v", err)
+			return options, fmt.Errorf("unable to get flag value\n%w", err)
 		}
 
 		options.Value = &value
@@ -64,7 +63,7 @@ func GetVariableCreateOptions(cmd *cobra.Command) tfe.VariableCreateOptions {
 		// The description of the variable.
 		description, err := cmd.Flags().GetString("description")
 		if err != nil {
-			logrus.Fatalf("unable to get flag description\n%v", err)
+			return options, fmt.Errorf("unable to get flag description\n%w", err)
 		}
 
 		options.Description = &description
@@ -73,7 +72,7 @@ func GetVariableCreateOptions(cmd *cobra.Command) tfe.VariableCreateOptions {
 	if cmd.Flags().Changed("category") {
 		category, err := cmd.Flags().GetString("category")
 		if err != nil {
-			logrus.Fatalf("unable to get flag category\n%v", err)
+			return options, fmt.Errorf("unable to get flag category\n%w", err)
 		}
 
 		switch category {
@@ -90,7 +89,7 @@ func GetVariableCreateOptions(cmd *cobra.Command) tfe.VariableCreateOptions {
 		// Whether to evaluate the value of the variable as a string of HCL code.
 		hcl, err := cmd.Flags().GetBool("hcl")
 		if err != nil {
-			logrus.Fatalf("unable to get flag hcl\n%v", err)
+			return options, fmt.Errorf("unable to get flag hcl\n%w", err)
 		}
 
 		options.HCL = &hcl
@@ -100,24 +99,24 @@ func GetVariableCreateOptions(cmd *cobra.Command) tfe.VariableCreateOptions {
 		// Whether the value is sensitive.
 		sensitive, err := cmd.Flags().GetBool("sensitive")
 		if err != nil {
-			logrus.Fatalf("unable to get flag sensitive\n%v", err)
+			return options, fmt.Errorf("unable to get flag sensitive\n%w", err)
 		}
 
 		options.Sensitive = &sensitive
 	}
 
-	return options
+	return options, nil
 }
 
 // GetVariableUpdateOptions return tfe.VariableUpdateOptions with correpondent values given by the flags
-func GetVariableUpdateOptions(cmd *cobra.Command) tfe.VariableUpdateOptions {
+func GetVariableUpdateOptions(cmd *cobra.Command) (tfe.VariableUpdateOptions, error) {
 	var options tfe.VariableUpdateOptions
 
 	if cmd.Flags().Changed("id") {
 		// The name of the variable.
 		id, err := cmd.Flags().GetString("id")
 		if err != nil {
-			logrus.Fatalf("unable to get flag id\n%v", err)
+			return options, fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
 		options.Key = &id
@@ -127,7 +126,7 @@ func GetVariableUpdateOptions(cmd *cobra.Command) tfe.VariableUpdateOptions {
 		// The name of the variable.
 		key, err := cmd.Flags().GetString("key")
 		if err != nil {
-			logrus.Fatalf("unable to get flag key\n%v", err)
+			return options, fmt.Errorf("unable to get flag key\n%w", err)
 		}
 
 		options.Key = &key
@@ -137,7 +136,7 @@ func GetVariableUpdateOptions(cmd *cobra.Command) tfe.VariableUpdateOptions {
 		// The value of the variable.
 		value, err := cmd.Flags().GetString("value")
 		if err != nil {
-			logrus.Fatalf("unable to get flag value\n%v", err)
+			return options, fmt.Errorf("unable to get flag value\n%w", err)
 		}
 
 		options.Value = &value
@@ -147,7 +146,7 @@ func GetVariableUpdateOptions(cmd *cobra.Command) tfe.VariableUpdateOptions {
 		// The description of the variable.
 		description, err := cmd.Flags().GetString("description")
 		if err != nil {
-			logrus.Fatalf("unable to get flag description\n%v", err)
+			return options, fmt.Errorf("unable to get flag description\n%w", err)
 		}
 
 		options.Description = &description
@@ -157,7 +156,7 @@ func GetVariableUpdateOptions(cmd *cobra.Command) tfe.VariableUpdateOptions {
 		// Whether to evaluate the value of the variable as a string of HCL code.
 		hcl, err := cmd.Flags().GetBool("hcl")
 		if err != nil {
-			logrus.Fatalf("unable to get flag hcl\n%v", err)
+			return options, fmt.Errorf("unable to get flag hcl\n%w", err)
 		}
 
 		options.HCL = &hcl
@@ -167,13 +166,13 @@ func GetVariableUpdateOptions(cmd *cobra.Command) tfe.VariableUpdateOptions {
 		// Whether the value is sensitive.
 		sensitive, err := cmd.Flags().GetBool("sensitive")
 		if err != nil {
-			logrus.Fatalf("unable to get flag sensitive\n%v", err)
+			return options, fmt.Errorf("unable to get flag sensitive\n%w", err)
 		}
 
 		options.Sensitive = &sensitive
 	}
 
-	return options
+	return options, nil
 }
 
 // PrintVariableList convert struct to JSON and displays to user
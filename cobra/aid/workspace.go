@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	tfe "github.com/hashicorp/go-tfe"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -64,6 +63,9 @@ func SetWorkspaceFlags(cmd *cobra.Command) {
 	// Create/Update/RemoveVCSConnectionOptions
 	SetVCSRepoFlags(cmd)
 
+	usage = `Used with create, update, update-by-id and list. The project name or ID (prj-XXXXXXXX): create places the workspace in the project, update moves it there, and list only lists the workspaces of the project.`
+	cmd.Flags().String("project", "", usage)
+
 	usage = `A relative path that Terraform will execute within. This defaults to the root of your repository and is typically set to a subdirectory matching the environment when multiple environments exist within the same repository.`
 	cmd.Flags().String("working-directory", "", usage)
 
@@ -74,6 +76,20 @@ func SetWorkspaceFlags(cmd *cobra.Command) {
 	// AssignSSHKey / UnassignSSHKey
 	usage = `The SSH key ID to assign to a workspace. Must be created on the organization.`
 	cmd.Flags().String("ssh-key-id", "", usage)
+
+	// Outputs, Graph
+	usage = `Used with outputs and graph. The format of the outputs: env (export statements, the default), json, tfvars or dotenv. The format of the graph: tree (the default), dot or mermaid.`
+	cmd.Flags().String("format", "", usage)
+
+	usage = `With env and dotenv, a prefix for the variable names, e.g. TF gives TF_VPC_ID.`
+	cmd.Flags().String("prefix", "", usage)
+
+	usage = `Include the sensitive outputs. They are left out otherwise.`
+	cmd.Flags().Bool("sensitive", false, usage)
+
+	// Graph
+	usage = `Used with graph. The number of workspaces whose run triggers are fetched at the same time.`
+	cmd.Flags().Int("concurrency", 8, usage)
 }
 
 // SetVCSRepoFlags define flags for the cobra command ..
@@ -96,12 +112,12 @@ func SetVCSRepoFlags(cmd *cobra.Command) {
 }
 
 // GetWorkspaceListOptions TODO ...
-func GetWorkspaceListOptions(cmd *cobra.Command) tfe.WorkspaceListOptions {
+func GetWorkspaceListOptions(cmd *cobra.Command) (tfe.WorkspaceListOptions, error) {
 	var options tfe.WorkspaceListOptions
 
 	search, err := cmd.Flags().GetString("search")
 	if err != nil {
-		logrus.Fatalf("unable to get flag search")
+		return options, fmt.Errorf("unable to get flag search\n%w", err)
 	}
 
 	if search != "" {
@@ -110,24 +126,24 @@ func GetWorkspaceListOptions(cmd *cobra.Command) tfe.WorkspaceListOptions {
 
 	include, err := cmd.Flags().GetString("include")
 	if err != nil {
-		logrus.Fatalf("unable to get flag include")
+		return options, fmt.Errorf("unable to get flag include\n%w", err)
 	}
 
 	if include != "" {
 		options.Include = []tfe.WSIncludeOpt{tfe.WSIncludeOpt(include)}
 	}
 
-	return options
+	return options, nil
 
 }
 
 // GetWorkspaceCreateOptions return options based on the flags values
-func GetWorkspaceCreateOptions(cmd *cobra.Command) tfe.WorkspaceCreateOptions {
+func GetWorkspaceCreateOptions(cmd *cobra.Command) (tfe.WorkspaceCreateOptions, error) {
 	var options tfe.WorkspaceCreateOptions
 
 	agentPoolID, err := cmd.Flags().GetString("agent-pool-id")
 	if err != nil {
-		logrus.Fatalf("unable to get flag agent-pool-id\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag agent-pool-id\n%w", err)
 	}
 	if agentPoolID != "" {
 		options.AgentPoolID = &agentPoolID
@@ -135,21 +151,21 @@ func GetWorkspaceCreateOptions(cmd *cobra.Command) tfe.WorkspaceCreateOptions {
 
 	allowDestroyPlan, err := cmd.Flags().GetBool("allow-destroy-plan")
 	if err != nil {
-		logrus.Fatalf("unable to get flag allow-destroy-plan\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag allow-destroy-plan\n%w", err)
 	}
 
 	options.AllowDestroyPlan = &allowDestroyPlan
 
 	autoApply, err := cmd.Flags().GetBool("auto-apply")
 	if err != nil {
-		logrus.Fatalf("unable to get flag auto-apply\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag auto-apply\n%w", err)
 	}
 
 	options.AutoApply = &autoApply
 
 	executionMode, err := cmd.Flags().GetString("execution-mode")
 	if err != nil {
-		logrus.Fatalf("unable to get flag execution-mode\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag execution-mode\n%w", err)
 	}
 	if executionMode != "" {
 		options.ExecutionMode = &executionMode
@@ -157,14 +173,14 @@ func GetWorkspaceCreateOptions(cmd *cobra.Command) tfe.WorkspaceCreateOptions {
 
 	fileTriggersEnabled, err := cmd.Flags().GetBool("file-triggers-enabled")
 	if err != nil {
-		logrus.Fatalf("unable to get flag file-triggers-enabled\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag file-triggers-enabled\n%w", err)
 	}
 
 	options.FileTriggersEnabled = &fileTriggersEnabled
 
 	migrationEnvironment, err := cmd.Flags().GetString("migration-environment")
 	if err != nil {
-		logrus.Fatalf("unable to get flag migration-environment\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag migration-environment\n%w", err)
 	}
 	if migrationEnvironment != "" {
 		options.MigrationEnvironment = &migrationEnvironment
@@ -172,7 +188,7 @@ func GetWorkspaceCreateOptions(cmd *cobra.Command) tfe.WorkspaceCreateOptions {
 
 	name, err := cmd.Flags().GetString("name")
 	if err != nil {
-		logrus.Fatalf("unable to get flag name\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag name\n%w", err)
 	}
 	if name != "" {
 		options.Name = &name
@@ -180,14 +196,14 @@ func GetWorkspaceCreateOptions(cmd *cobra.Command) tfe.WorkspaceCreateOptions {
 
 	queueAllRuns, err := cmd.Flags().GetBool("queue-all-runs")
 	if err != nil {
-		logrus.Fatalf("unable to get flag queue-all-runs\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag queue-all-runs\n%w", err)
 	}
 
 	options.QueueAllRuns = &queueAllRuns
 
 	speculativeEnabled, err := cmd.Flags().GetBool("speculative-enabled")
 	if err != nil {
-		logrus.Fatalf("unable to get flag speculative-enabled\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag speculative-enabled\n%w", err)
 	}
 	if speculativeEnabled {
 		options.SpeculativeEnabled = &speculativeEnabled
@@ -195,7 +211,7 @@ func GetWorkspaceCreateOptions(cmd *cobra.Command) tfe.WorkspaceCreateOptions {
 
 	terraformVersion, err := cmd.Flags().GetString("terraform-version")
 	if err != nil {
-		logrus.Fatalf("unable to get flag terraform-version\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag terraform-version\n%w", err)
 	}
 	if terraformVersion != "" {
 		options.TerraformVersion = &terraformVersion
@@ -203,13 +219,16 @@ func GetWorkspaceCreateOptions(cmd *cobra.Command) tfe.WorkspaceCreateOptions {
 
 	triggerPrefixes, err := cmd.Flags().GetStringArray("trigger-prefixes")
 	if err != nil {
-		logrus.Fatalf("unable to get flag trigger-prefixes\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag trigger-prefixes\n%w", err)
 	}
 	if len(triggerPrefixes) > 0 {
 		options.TriggerPrefixes = triggerPrefixes
 	}
 
-	repoOptions := GetVCSRepoFlags(cmd)
+	repoOptions, err := GetVCSRepoFlags(cmd)
+	if err != nil {
+		return options, err
+	}
 	// repoOptions := tfe.VCSRepoOptions{}
 	if repoOptions != (tfe.VCSRepoOptions{}) {
 		options.VCSRepo = &repoOptions
@@ -217,23 +236,23 @@ func GetWorkspaceCreateOptions(cmd *cobra.Command) tfe.WorkspaceCreateOptions {
 
 	workingDirectory, err := cmd.Flags().GetString("working-directory")
 	if err != nil {
-		logrus.Fatalf("unable to get flag working-directory\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag working-directory\n%w", err)
 	}
 	if workingDirectory != "" {
 		options.WorkingDirectory = &workingDirectory
 	}
 
-	return options
+	return options, nil
 
 }
 
 // GetVCSRepoFlags define flags for the cobra command
-func GetVCSRepoFlags(cmd *cobra.Command) tfe.VCSRepoOptions {
+func GetVCSRepoFlags(cmd *cobra.Command) (tfe.VCSRepoOptions, error) {
 	var options tfe.VCSRepoOptions
 
 	vcsRepoBranch, err := cmd.Flags().GetString("vcs-repo-branch")
 	if err != nil {
-		logrus.Fatalf("unable to get flag vcsRepoBranch\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag vcsRepoBranch\n%w", err)
 	}
 	if vcsRepoBranch != "" {
 		options.Branch = &vcsRepoBranch
@@ -241,7 +260,7 @@ func GetVCSRepoFlags(cmd *cobra.Command) tfe.VCSRepoOptions {
 
 	vcsRepoIdentifier, err := cmd.Flags().GetString("vcs-repo-identifier")
 	if err != nil {
-		logrus.Fatalf("unable to get flag vcsRepoIdentifier\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag vcsRepoIdentifier\n%w", err)
 	}
 	if vcsRepoIdentifier != "" {
 		options.Identifier = &vcsRepoIdentifier
@@ -251,7 +270,7 @@ func GetVCSRepoFlags(cmd *cobra.Command) tfe.VCSRepoOptions {
 	if cmd.Flags().Changed("vcs-repo-ingress-submodules") {
 		vcsRepoIngressSubmodules, err := cmd.Flags().GetBool("vcs-repo-ingress-submodules")
 		if err != nil {
-			logrus.Fatalf("unable to get flag vcsRepoIngressSubmodules\n%v\n", err)
+			return options, fmt.Errorf("unable to get flag vcsRepoIngressSubmodules\n%w", err)
 		}
 
 		options.IngressSubmodules = &vcsRepoIngressSubmodules
@@ -259,17 +278,17 @@ func GetVCSRepoFlags(cmd *cobra.Command) tfe.VCSRepoOptions {
 
 	vcsRepoOauthTokenID, err := cmd.Flags().GetString("vcs-repo-oauth-token-id")
 	if err != nil {
-		logrus.Fatalf("unable to get flag vcsRepoOauthTokenId\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag vcsRepoOauthTokenId\n%w", err)
 	}
 	if vcsRepoOauthTokenID != "" {
 		options.OAuthTokenID = &vcsRepoOauthTokenID
 	}
 
-	return options
+	return options, nil
 }
 
 // GetWorkspaceUpdateOptions return options based on the flag values
-func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
+func GetWorkspaceUpdateOptions(cmd *cobra.Command) (tfe.WorkspaceUpdateOptions, error) {
 	var options tfe.WorkspaceUpdateOptions
 
 	// Required when execution-mode is set to agent. The ID of the agent pool
@@ -277,7 +296,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// if execution-mode is set to remote or local or if operations is set to true.
 	agentPoolID, err := cmd.Flags().GetString("agent-pool-id")
 	if err != nil {
-		logrus.Fatalf("unable to get flag agent-pool-id\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag agent-pool-id\n%w", err)
 	}
 	if agentPoolID != "" {
 		options.AgentPoolID = &agentPoolID
@@ -286,7 +305,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// Whether destroy plans can be queued on the workspace.
 	allowDestroyPlan, err := cmd.Flags().GetBool("allow-destroy-plan")
 	if err != nil {
-		logrus.Fatalf("unable to get flag allow-destroy-plan\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag allow-destroy-plan\n%w", err)
 	}
 
 	options.AllowDestroyPlan = &allowDestroyPlan
@@ -294,7 +313,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	autoApply, err := cmd.Flags().GetBool("auto-apply")
 	if err != nil {
-		logrus.Fatalf("unable to get flag auto-apply\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag auto-apply\n%w", err)
 	}
 
 	options.AutoApply = &autoApply
@@ -305,7 +324,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// API and UI.
 	newName, err := cmd.Flags().GetString("new-name")
 	if err != nil {
-		logrus.Fatalf("unable to get flag new-name\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag new-name\n%w", err)
 	}
 
 	if newName != "" {
@@ -318,7 +337,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// 'agent' execution mode is not available in Terraform Enterprise.
 	executionMode, err := cmd.Flags().GetString("execution-mode")
 	if err != nil {
-		logrus.Fatalf("unable to get flag execution-mode\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag execution-mode\n%w", err)
 	}
 
 	if executionMode != "" {
@@ -331,7 +350,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// disabled, any push will trigger a run.
 	fileTriggersEnabled, err := cmd.Flags().GetBool("file-triggers-enabled")
 	if err != nil {
-		logrus.Fatalf("unable to get flag file-triggers-enabled\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag file-triggers-enabled\n%w", err)
 	}
 
 	options.FileTriggersEnabled = &fileTriggersEnabled
@@ -340,7 +359,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// a webhook will not be queued until at least one run is manually queued.
 	queueAllRuns, err := cmd.Flags().GetBool("queue-all-runs")
 	if err != nil {
-		logrus.Fatalf("unable to get flag queue-all-runs\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag queue-all-runs\n%w", err)
 	}
 
 	options.QueueAllRuns = &queueAllRuns
@@ -351,7 +370,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// repository is public or includes untrusted contributors.
 	speculativeEnabled, err := cmd.Flags().GetBool("speculative-enabled")
 	if err != nil {
-		logrus.Fatalf("unable to get flag speculative-enabled\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag speculative-enabled\n%w", err)
 	}
 
 	options.SpeculativeEnabled = &speculativeEnabled
@@ -359,7 +378,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// The version of Terraform to use for this workspace.
 	terraformVersion, err := cmd.Flags().GetString("terraform-version")
 	if err != nil {
-		logrus.Fatalf("unable to get flag terraform-version\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag terraform-version\n%w", err)
 	}
 
 	if terraformVersion != "" {
@@ -370,7 +389,7 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// tracked for changes. See FileTriggersEnabled above for more details.
 	triggerPrefixes, err := cmd.Flags().GetStringArray("trigger-prefixes")
 	if err != nil {
-		logrus.Fatalf("unable to get flag trigger-prefixes\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag trigger-prefixes\n%w", err)
 	}
 
 	if len(triggerPrefixes) > 0 {
@@ -383,7 +402,10 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// that didn't previously have one, include at least the oauth-token-id and
 	// identifier keys.
 
-	repoOptions := GetVCSRepoFlags(cmd)
+	repoOptions, err := GetVCSRepoFlags(cmd)
+	if err != nil {
+		return options, err
+	}
 	if repoOptions != (tfe.VCSRepoOptions{}) {
 		options.VCSRepo = &repoOptions
 	}
@@ -394,30 +416,30 @@ func GetWorkspaceUpdateOptions(cmd *cobra.Command) tfe.WorkspaceUpdateOptions {
 	// repository.
 	workingDirectory, err := cmd.Flags().GetString("working-directory")
 	if err != nil {
-		logrus.Fatalf("unable to get flag working-directory\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag working-directory\n%w", err)
 	}
 
 	if workingDirectory != "" {
 		options.WorkingDirectory = &workingDirectory
 	}
 
-	return options
+	return options, nil
 }
 
 // GetWorkspaceAssignSSHKeyOptions return options based on the command's flags value
-func GetWorkspaceAssignSSHKeyOptions(cmd *cobra.Command) tfe.WorkspaceAssignSSHKeyOptions {
+func GetWorkspaceAssignSSHKeyOptions(cmd *cobra.Command) (tfe.WorkspaceAssignSSHKeyOptions, error) {
 	var options tfe.WorkspaceAssignSSHKeyOptions
 
 	sshKeyID, err := cmd.Flags().GetString("ssh-key-id")
 	if err != nil {
-		logrus.Fatalf("unable to get flag ssh-key-id\n%v\n", err)
+		return options, fmt.Errorf("unable to get flag ssh-key-id\n%w", err)
 	}
 
 	if sshKeyID != "" {
 		options.SSHKeyID = &sshKeyID
 	}
 
-	return options
+	return options, nil
 }
 
 // PrintWorkspaceList TODO ...
@@ -432,3 +454,20 @@ func PrintWorkspaceList(list *tfe.WorkspaceList) {
 		}
 	}
 }
+
+// GetWorkspaceFormat returns --format, or the default format of the workspace argument when it isn't given
+func GetWorkspaceFormat(cmd *cobra.Command, arg string) (string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	if format != "" {
+		return format, nil
+	}
+
+	if arg == "graph" {
+		return WorkspaceGraphFormats[0], nil
+	}
+	return WorkspaceOutputFormats[0], nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aid
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// WorkspaceOutputFormats are the output formats of workspace outputs
+var WorkspaceOutputFormats = []string{"env", "json", "tfvars", "dotenv"}
+
+// envNameInvalidChars matches the characters that aren't allowed in an environment variable name
+var envNameInvalidChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// GetWorkspaceOutputValues returns the values of the outputs by name
+func GetWorkspaceOutputValues(outputs []*tfe.StateVersionOutput) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, o := range outputs {
+		values[o.Name] = o.Value
+	}
+
+	return values
+}
+
+// FlattenOutputs flattens the output values into environment variables, nested maps and lists
+// giving PREFIX_KEY_NESTEDKEY and PREFIX_KEY_0 names
+func FlattenOutputs(values map[string]interface{}, prefix string) (map[string]string, error) {
+	vars := make(map[string]string)
+	sources := make(map[string]string)
+
+	var flatten func(name string, path string, value interface{}) error
+	flatten = func(name string, path string, value interface{}) error {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, nested := range v {
+				if err := flatten(name+"_"+key, path+"."+key, nested); err != nil {
+					return err
+				}
+			}
+			return nil
+		case []interface{}:
+			for i, nested := range v {
+				if err := flatten(fmt.Sprintf("%s_%d", name, i), fmt.Sprintf("%s[%d]", path, i), nested); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		name = envNameInvalidChars.ReplaceAllString(strings.ToUpper(name), "_")
+		if name[0] >= '0' && name[0] <= '9' {
+			name = "_" + name
+		}
+
+		if source, ok := sources[name]; ok {
+			return fmt.Errorf("outputs %s and %s both give the environment variable %s, use --format json instead", source, path, name)
+		}
+		sources[name] = path
+
+		s, err := formatScalarOutput(value)
+		if err != nil {
+			return fmt.Errorf("output %s\n%w", path, err)
+		}
+		vars[name] = s
+
+		return nil
+	}
+
+	// outputs are flattened in name order, so a conflict is always reported the same way
+	for _, key := range sortedKeys(values) {
+		name := key
+		if prefix != "" {
+			name = prefix + "_" + key
+		}
+
+		if err := flatten(name, key, values[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return vars, nil
+}
+
+// formatScalarOutput formats a string, number, bool or null output value
+func formatScalarOutput(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+// RenderWorkspaceOutputs renders the output values in the given format
+func RenderWorkspaceOutputs(values map[string]interface{}, format string, prefix string) (string, error) {
+	var b strings.Builder
+
+	switch format {
+	case "json":
+		return ToJSON(values) + "\n", nil
+	case "tfvars":
+		for _, key := range sortedKeys(values) {
+			fmt.Fprintf(&b, "%s = %s\n", key, hclLiteral(values[key], ""))
+		}
+	case "env", "dotenv":
+		vars, err := FlattenOutputs(values, prefix)
+		if err != nil {
+			return "", err
+		}
+
+		names := make([]string, 0, len(vars))
+		for name := range vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if format == "env" {
+				fmt.Fprintf(&b, "export %s=%s\n", name, shellQuote(vars[name]))
+			} else {
+				fmt.Fprintf(&b, "%s=%s\n", name, dotenvQuote(vars[name]))
+			}
+		}
+	default:
+		return "", fmt.Errorf("unsupported format %s", format)
+	}
+
+	return b.String(), nil
+}
+
+// shellQuote single-quotes a value for a POSIX shell, a single quote in the value ending and reopening the quoting
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// dotenvQuote quotes a value the way dotenv files read it back, escaping newlines and quotes
+func dotenvQuote(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`)
+	return `"` + r.Replace(value) + `"`
+}
+
+// hclLiteral formats an output value as an HCL expression, maps being indented on several lines
+func hclLiteral(value interface{}, indent string) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		b, _ := json.Marshal(v)
+		// ${ and %{ start a template sequence in an HCL string
+		s := strings.ReplaceAll(string(b), "${", "$${")
+		return strings.ReplaceAll(s, "%{", "%%{")
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = hclLiteral(item, indent)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}"
+		}
+
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range sortedKeys(v) {
+			fmt.Fprintf(&b, "%s  %s = %s\n", indent, hclLiteral(key, ""), hclLiteral(v[key], indent+"  "))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	default:
+		s, _ := formatScalarOutput(v)
+		return s
+	}
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -16,7 +16,6 @@ limitations under the License.
 package cmd
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/awslabs/tecli/cobra/controller"
@@ -27,8 +26,9 @@ var rootCmd = controller.RootCmd()
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	command, err := rootCmd.ExecuteC()
+	controller.AuditCommand(command, err)
+	if err != nil {
+		os.Exit(controller.ReportError(command, err))
 	}
 }
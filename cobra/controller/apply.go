@@ -20,12 +20,12 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
 	"github.com/awslabs/tecli/helper"
 	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -35,8 +35,7 @@ var applyValidArgs = []string{"read", "logs"}
 func ApplyCmd() *cobra.Command {
 	man, err := helper.GetManual("apply", applyValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -75,7 +74,10 @@ func applyPreRun(cmd *cobra.Command, args []string) error {
 func applyRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	fArg := args[0]
 	switch fArg {
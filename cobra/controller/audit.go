@@ -0,0 +1,199 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var auditValidArgs = []string{"list"}
+
+// unauditedCommands only touch local files, they never call the API
+var unauditedCommands = []string{"audit", "configure"}
+
+// runningCmd is the command being run, so that it can still be journaled if it exits through logrus.Fatal
+var runningCmd *cobra.Command
+
+// auditResources are the resources a command acted on beyond those given by its flags,
+// such as the runs of a bulk run action
+var auditResources []string
+
+// AuditCmd command to query the local audit journal
+func AuditCmd() *cobra.Command {
+	man, err := helper.GetManual("audit", auditValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    auditValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      auditPreRun,
+		RunE:         auditRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetAuditFlags(cmd)
+
+	return cmd
+}
+
+func auditPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgsV2(cmd, args); err != nil {
+		return err
+	}
+
+	if _, err := aid.GetAuditSince(cmd); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func auditRun(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "list":
+		since, err := aid.GetAuditSince(cmd)
+		if err != nil {
+			return err
+		}
+
+		resource := helper.GetCmdFlagString(cmd, "resource")
+		entries, err := aid.ReadAuditEntries(since, resource)
+		if err != nil {
+			return fmt.Errorf("unable to list audit entries\n%w", err)
+		}
+
+		aid.PrintAuditList(entries)
+	default:
+		return fmt.Errorf("unknown argument provided")
+	}
+
+	return nil
+}
+
+// AuditCommand records the outcome of a mutating command in the audit journal
+func AuditCommand(cmd *cobra.Command, err error) {
+	if cmd == nil || helper.ContainsString(unauditedCommands, cmd.Name()) {
+		return
+	}
+
+	args := cmd.Flags().Args()
+	if len(args) == 0 || !helper.ContainsString(cmd.ValidArgs, args[0]) || !aid.IsMutatingArgument(args[0]) {
+		return
+	}
+
+	entry := model.AuditEntry{
+		Command:   strings.Join(aid.RedactArgs(append([]string{"tecli"}, os.Args[1:]...)), " "),
+		Resources: append(aid.GetAuditResources(cmd), auditResources...),
+		Result:    "success",
+	}
+
+	// arguments such as policy-check override take the reason of the change
+	if cmd.Flags().Lookup("reason") != nil {
+		entry.Reason = helper.GetCmdFlagString(cmd, "reason")
+	}
+
+	if err != nil && aid.ClassifyError(err).Kind != aid.ErrorKindChanges {
+		entry.Result = "failure"
+		entry.Error = err.Error()
+	}
+
+	if err := recordAudit(entry); err != nil {
+		logrus.Errorf("unable to record audit entry\n%v", err)
+	}
+}
+
+// addAuditResources adds resources the running command acted on to its audit entry
+func addAuditResources(resources ...string) {
+	auditResources = append(auditResources, resources...)
+}
+
+// auditFatalHook journals the command that exits through logrus.Fatal, as such a
+// command returns neither to ExecuteC nor to AuditCommand
+type auditFatalHook struct {
+	fired bool
+}
+
+// Levels implements logrus.Hook
+func (h *auditFatalHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.FatalLevel}
+}
+
+// Fire implements logrus.Hook
+func (h *auditFatalHook) Fire(entry *logrus.Entry) error {
+	// recording the entry may itself be fatal
+	if h.fired {
+		return nil
+	}
+	h.fired = true
+
+	err := errors.New(entry.Message)
+	if runningCmd != nil {
+		AuditCommand(runningCmd, err)
+		return nil
+	}
+
+	auditCommandLine(os.Args[1:], err)
+	return nil
+}
+
+// auditCommandLine journals a command that failed before it was resolved, e.g. while
+// its manual was loaded, from its command line: tecli <command> <argument> [flags]
+func auditCommandLine(args []string, err error) {
+	if len(args) < 2 || strings.HasPrefix(args[0], "-") || strings.HasPrefix(args[1], "-") {
+		return
+	}
+
+	if helper.ContainsString(unauditedCommands, args[0]) || !aid.IsMutatingArgument(args[1]) {
+		return
+	}
+
+	entry := model.AuditEntry{
+		Command: strings.Join(aid.RedactArgs(append([]string{"tecli"}, args...)), " "),
+		Result:  "failure",
+		Error:   err.Error(),
+	}
+
+	if err := recordAudit(entry); err != nil {
+		logrus.Errorf("unable to record audit entry\n%v", err)
+	}
+}
+
+// recordAudit fills in who and where before appending the entry to the journal
+func recordAudit(entry model.AuditEntry) error {
+	entry.Timestamp = time.Now().UTC()
+	entry.User = aid.GetOSUsername()
+	entry.Profile = profile
+	entry.Organization = dao.GetOrganization(profile)
+
+	return aid.WriteAuditEntry(entry, dao.GetAuditSink(profile))
+}
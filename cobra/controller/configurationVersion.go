@@ -24,6 +24,7 @@ import (
 	"github.com/awslabs/tecli/cobra/dao"
 	"github.com/awslabs/tecli/helper"
 	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -33,8 +34,7 @@ var configurationVersionValidArgs = []string{"list", "create", "read", "upload"}
 func ConfigurationVersionCmd() *cobra.Command {
 	man, err := helper.GetManual("configuration-version", configurationVersionValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -89,7 +89,10 @@ func configurationVersionPreRun(cmd *cobra.Command, args []string) error {
 func configurationVersionRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	fArg := args[0]
 	switch fArg {
@@ -103,7 +106,7 @@ func configurationVersionRun(cmd *cobra.Command, args []string) error {
 		if err == nil {
 			aid.PrintConfigurationVersionList(list)
 		} else {
-			return fmt.Errorf("no configurationVersion was found")
+			return fmt.Errorf("no configurationVersion was found\n%w", err)
 		}
 
 	case "create":
@@ -112,7 +115,10 @@ func configurationVersionRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag workspace-id\n%w", err)
 		}
 
-		options := aid.GetConfigurationVersionCreateOptions(cmd)
+		options, err := aid.GetConfigurationVersionCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
 		cv, err := configurationVersionCreate(client, workspaceID, options)
 
 		if err == nil && cv.ID != "" {
@@ -17,7 +17,6 @@ package controller
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
@@ -34,8 +33,7 @@ var configureValidArgs = []string{"list", "create", "read", "update", "delete"}
 func ConfigureCmd() *cobra.Command {
 	man, err := helper.GetManual("configure", configureValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -0,0 +1,151 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var costEstimateValidArgs = []string{"read", "read-for-run"}
+
+// CostEstimateCmd command to read the cost estimates of runs
+func CostEstimateCmd() *cobra.Command {
+	man, err := helper.GetManual("cost-estimate", costEstimateValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    costEstimateValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      costEstimatePreRun,
+		RunE:         costEstimateRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetCostEstimateFlags(cmd)
+
+	return cmd
+}
+
+func costEstimatePreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "cost-estimate"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "read":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "cost-estimate", fArg, "id"); err != nil {
+			return err
+		}
+	case "read-for-run":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "cost-estimate", fArg, "run-id"); err != nil {
+			return err
+		}
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	if !helper.ContainsString(aid.CostEstimateFormats, format) {
+		return fmt.Errorf("invalid --format %q, valid values are: %s", format, strings.Join(aid.CostEstimateFormats, ", "))
+	}
+
+	return nil
+}
+
+func costEstimateRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+
+	var ce *tfe.CostEstimate
+	fArg := args[0]
+	switch fArg {
+	case "read":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		ce, err = costEstimateRead(client, id)
+		if err != nil {
+			return fmt.Errorf("cost estimate %s not found\n%w", id, err)
+		}
+	case "read-for-run":
+		runID, err := cmd.Flags().GetString("run-id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag run-id\n%w", err)
+		}
+
+		ce, err = costEstimateReadForRun(client, runID)
+		if err != nil {
+			return err
+		}
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	if format == "json" {
+		fmt.Println(aid.ToJSON(ce))
+	} else {
+		fmt.Print(aid.RenderCostEstimate(ce))
+	}
+
+	return nil
+}
+
+// Read a cost estimate by its ID.
+func costEstimateRead(client *tfe.Client, costEstimateID string) (*tfe.CostEstimate, error) {
+	return client.CostEstimates.Read(context.Background(), costEstimateID)
+}
+
+// costEstimateReadForRun reads the cost estimate of a run
+func costEstimateReadForRun(client *tfe.Client, runID string) (*tfe.CostEstimate, error) {
+	options := tfe.RunReadOptions{Include: []tfe.RunIncludeOpt{tfe.RunCostEstimate}}
+	run, err := runReadWithOptions(client, runID, &options)
+	if err != nil {
+		return nil, fmt.Errorf("run %s not found\n%w", runID, err)
+	}
+
+	if run.CostEstimate == nil {
+		return nil, fmt.Errorf("run %s has no cost estimate, cost estimation may be disabled for the organization", runID)
+	}
+
+	return run.CostEstimate, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/spf13/cobra"
+)
+
+var errorFormat string
+
+var errorFormats = []string{"text", "json"}
+
+// ReportError prints the error on stderr in the format given by --error-format and returns the exit code
+func ReportError(cmd *cobra.Command, err error) int {
+	classified := aid.ClassifyError(err)
+	if classified.Kind == aid.ErrorKindChanges {
+		// not a failure, the summary of the changes is already printed
+		return classified.Code
+	}
+
+	if classified.Resource == "" && cmd != nil {
+		if resources := aid.GetAuditResources(cmd); len(resources) > 0 {
+			classified.Resource = resources[0]
+		}
+	}
+
+	if errorFormat == "json" {
+		b, jsonErr := json.Marshal(classified)
+		if jsonErr == nil {
+			fmt.Fprintln(os.Stderr, string(b))
+			return classified.Code
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %s\n", classified.Message)
+	return classified.Code
+}
+
+// validateErrorFormat checks the value given to --error-format
+func validateErrorFormat() error {
+	for _, format := range errorFormats {
+		if errorFormat == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid --error-format %q, valid values are: %s", errorFormat, strings.Join(errorFormats, ", "))
+}
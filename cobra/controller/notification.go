@@ -0,0 +1,316 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var notificationValidArgs = []string{
+	"list",
+	"create",
+	"read",
+	"update",
+	"delete",
+	"verify",
+	"listen"}
+
+// NotificationCmd command to manage the notification configurations of workspaces
+func NotificationCmd() *cobra.Command {
+	man, err := helper.GetManual("notification", notificationValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    notificationValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      notificationPreRun,
+		RunE:         notificationRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetNotificationFlags(cmd)
+
+	return cmd
+}
+
+func notificationPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "notification"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "notification", fArg, "workspace-id"); err != nil {
+			return err
+		}
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "notification", fArg, "workspace-id"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "notification", fArg, "name"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "notification", fArg, "destination-type"); err != nil {
+			return err
+		}
+
+		if _, err := aid.GetNotificationCreateOptions(cmd); err != nil {
+			return err
+		}
+
+	case "read", "update", "delete", "verify":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "notification", fArg, "id"); err != nil {
+			return err
+		}
+
+		if fArg == "update" {
+			if _, err := aid.GetNotificationUpdateOptions(cmd); err != nil {
+				return err
+			}
+		}
+
+	case "listen":
+		port, err := cmd.Flags().GetInt("port")
+		if err != nil {
+			return fmt.Errorf("unable to get flag port\n%w", err)
+		}
+
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid --port %d, it must be between 1 and 65535", port)
+		}
+
+	default:
+		return fmt.Errorf("unknown argument")
+	}
+
+	return nil
+}
+
+func notificationRun(cmd *cobra.Command, args []string) error {
+	fArg := args[0]
+	if fArg == "listen" {
+		// the receiver works offline, without any token or organization
+		return notificationListen(cmd)
+	}
+
+	token := dao.GetOrganizationToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+
+	switch fArg {
+	case "list":
+		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag workspace-id\n%w", err)
+		}
+
+		list, err := notificationListAll(client, workspaceID)
+		if err != nil {
+			return fmt.Errorf("unable to list the notification configurations of workspace %s\n%w", workspaceID, err)
+		}
+		fmt.Print(aid.RenderNotificationConfigurations(list))
+
+	case "create":
+		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag workspace-id\n%w", err)
+		}
+
+		options, err := aid.GetNotificationCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		nc, err := notificationCreate(client, workspaceID, options)
+		if err != nil {
+			return fmt.Errorf("unable to create notification configuration\n%w", err)
+		}
+		fmt.Println(aid.ToJSON(nc))
+
+	case "read":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		nc, err := notificationRead(client, id)
+		if err != nil {
+			return fmt.Errorf("notification configuration %s not found\n%w", id, err)
+		}
+		fmt.Println(aid.ToJSON(nc))
+
+	case "update":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		options, err := aid.GetNotificationUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		nc, err := notificationUpdate(client, id, options)
+		if err != nil {
+			return fmt.Errorf("unable to update notification configuration %s\n%w", id, err)
+		}
+		fmt.Println(aid.ToJSON(nc))
+
+	case "delete":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		if err := notificationDelete(client, id); err != nil {
+			return fmt.Errorf("unable to delete notification configuration %s\n%w", id, err)
+		}
+		cmd.Printf("notification configuration %s deleted successfully\n", id)
+
+	case "verify":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		nc, err := notificationVerify(client, id)
+		if err != nil {
+			return fmt.Errorf("unable to verify notification configuration %s\n%w", id, err)
+		}
+		fmt.Print(aid.RenderNotificationDeliveries(nc))
+
+		for _, r := range nc.DeliveryResponses {
+			if r.Successful != "true" {
+				return fmt.Errorf("the verification payload of notification configuration %s wasn't delivered", id)
+			}
+		}
+
+	default:
+		return fmt.Errorf("unknown argument provided")
+	}
+
+	return nil
+}
+
+// notificationListen receives notification payloads on --host and --port and prints them until interrupted
+func notificationListen(cmd *cobra.Command) error {
+	host, err := cmd.Flags().GetString("host")
+	if err != nil {
+		return fmt.Errorf("unable to get flag host\n%w", err)
+	}
+
+	port, err := cmd.Flags().GetInt("port")
+	if err != nil {
+		return fmt.Errorf("unable to get flag port\n%w", err)
+	}
+
+	token, err := cmd.Flags().GetString("token")
+	if err != nil {
+		return fmt.Errorf("unable to get flag token\n%w", err)
+	}
+
+	if token == "" {
+		logrus.Warnln("no --token given, the signatures of the payloads aren't verified")
+	}
+
+	server := &http.Server{
+		Addr:              net.JoinHostPort(host, strconv.Itoa(port)),
+		Handler:           aid.NewNotificationHandler(token, os.Stdout),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("listening for notifications on http://%s, press Ctrl+C to stop\n", server.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("unable to listen on %s\n%w", server.Addr, err)
+	}
+
+	return nil
+}
+
+// notificationListAll pages through the notification configurations of a workspace
+func notificationListAll(client *tfe.Client, workspaceID string) ([]*tfe.NotificationConfiguration, error) {
+	var configurations []*tfe.NotificationConfiguration
+	options := tfe.NotificationConfigurationListOptions{ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100}}
+	for {
+		list, err := client.NotificationConfigurations.List(context.Background(), workspaceID, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		configurations = append(configurations, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return configurations, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
+
+func notificationCreate(client *tfe.Client, workspaceID string, options tfe.NotificationConfigurationCreateOptions) (*tfe.NotificationConfiguration, error) {
+	return client.NotificationConfigurations.Create(context.Background(), workspaceID, options)
+}
+
+func notificationRead(client *tfe.Client, notificationID string) (*tfe.NotificationConfiguration, error) {
+	return client.NotificationConfigurations.Read(context.Background(), notificationID)
+}
+
+func notificationUpdate(client *tfe.Client, notificationID string, options tfe.NotificationConfigurationUpdateOptions) (*tfe.NotificationConfiguration, error) {
+	return client.NotificationConfigurations.Update(context.Background(), notificationID, options)
+}
+
+func notificationDelete(client *tfe.Client, notificationID string) error {
+	return client.NotificationConfigurations.Delete(context.Background(), notificationID)
+}
+
+func notificationVerify(client *tfe.Client, notificationID string) (*tfe.NotificationConfiguration, error) {
+	return client.NotificationConfigurations.Verify(context.Background(), notificationID)
+}
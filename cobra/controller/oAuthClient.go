@@ -18,12 +18,12 @@ package controller
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
 	"github.com/awslabs/tecli/helper"
 	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -38,8 +38,7 @@ var oAuthClientValidArgs = []string{
 func OAuthClientCmd() *cobra.Command {
 	man, err := helper.GetManual("o-auth-client", oAuthClientValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -95,7 +94,10 @@ func oAuthClientPreRun(cmd *cobra.Command, args []string) error {
 func oAuthClientRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetOrganizationToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	fArg := args[0]
 	switch fArg {
@@ -105,12 +107,15 @@ func oAuthClientRun(cmd *cobra.Command, args []string) error {
 		if err == nil {
 			aid.PrintOAuthClientList(list)
 		} else {
-			return fmt.Errorf("no o-auth-clients was found")
+			return fmt.Errorf("no o-auth-clients was found\n%w", err)
 		}
 
 	case "create":
 		organization := dao.GetOrganization(profile)
-		options := aid.GetOAuthClientCreateOptions(cmd)
+		options, err := aid.GetOAuthClientCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
 		oAuthClient, err := oAuthClientCreate(client, organization, options)
 
 		if err == nil && oAuthClient.ID != "" {
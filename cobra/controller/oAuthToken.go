@@ -18,12 +18,12 @@ package controller
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
 	"github.com/awslabs/tecli/helper"
 	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -38,8 +38,7 @@ var oAuthTokenValidArgs = []string{
 func OAuthTokenCmd() *cobra.Command {
 	man, err := helper.GetManual("o-auth-token", oAuthTokenValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -78,7 +77,10 @@ func oAuthTokenPreRun(cmd *cobra.Command, args []string) error {
 func oAuthTokenRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetOrganizationToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	fArg := args[0]
 	switch fArg {
@@ -88,7 +90,7 @@ func oAuthTokenRun(cmd *cobra.Command, args []string) error {
 		if err == nil {
 			aid.PrintOAuthTokenList(list)
 		} else {
-			return fmt.Errorf("no o-auth-tokens was found")
+			return fmt.Errorf("no o-auth-tokens was found\n%w", err)
 		}
 	case "read":
 		id, err := cmd.Flags().GetString("id")
@@ -108,7 +110,10 @@ func oAuthTokenRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetOAuthTokenUpdateOptions(cmd)
+		options, err := aid.GetOAuthTokenUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
 		oAuthToken, err := oAuthTokenUpdate(client, id, options)
 
 		if err == nil && oAuthToken.ID != "" {
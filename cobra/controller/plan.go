@@ -21,22 +21,24 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/cobra/model"
 	"github.com/awslabs/tecli/helper"
 	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
-var planValidArgs = []string{"read", "logs"}
+var planValidArgs = []string{"read", "logs", "json", "summary"}
 
 // PlanCmd command to display tecli current version
 func PlanCmd() *cobra.Command {
 	man, err := helper.GetManual("plan", planValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -63,19 +65,39 @@ func planPreRun(cmd *cobra.Command, args []string) error {
 
 	fArg := args[0]
 	switch fArg {
-	case "read", "logs":
+	case "read", "logs", "json", "summary":
 		if err := helper.ValidateCmdArgAndFlag(cmd, args, "plan", fArg, "id"); err != nil {
 			return err
 		}
 	}
 
+	if fArg != "summary" && cmd.Flags().Changed("format") {
+		return fmt.Errorf("--format can only be used with plan summary")
+	}
+
+	if fArg != "json" && cmd.Flags().Changed("output") {
+		return fmt.Errorf("--output can only be used with plan json")
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	if !helper.ContainsString(aid.PlanSummaryFormats, format) {
+		return fmt.Errorf("invalid --format %q, valid values are: %s", format, strings.Join(aid.PlanSummaryFormats, ", "))
+	}
+
 	return nil
 }
 
 func planRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	fArg := args[0]
 	switch fArg {
@@ -102,6 +124,52 @@ func planRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to read plan logs\n%w", err)
 		}
 		fmt.Println(StreamToString(logs))
+	case "json":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return fmt.Errorf("unable to get flag output\n%w", err)
+		}
+
+		data, err := planReadJSONOutput(client, id)
+		if err != nil {
+			return fmt.Errorf("unable to read the JSON output of plan %s\n%w", id, err)
+		}
+
+		if output == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if err := os.WriteFile(output, data, 0600); err != nil {
+			return fmt.Errorf("unable to write the JSON output of plan %s to %s\n%w", id, output, err)
+		}
+		fmt.Printf("plan %s written to %s\n", id, output)
+	case "summary":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("unable to get flag format\n%w", err)
+		}
+
+		summary, err := planReadSummary(client, id)
+		if err != nil {
+			return err
+		}
+
+		out, err := aid.RenderPlanSummary(summary, format)
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
 	}
 
 	return nil
@@ -119,6 +187,26 @@ func planLogs(client *tfe.Client, planID string) (io.Reader, error) {
 
 }
 
+// planReadJSONOutput reads the JSON execution plan
+func planReadJSONOutput(client *tfe.Client, planID string) ([]byte, error) {
+	return client.Plans.ReadJSONOutput(context.Background(), planID)
+}
+
+// planReadSummary reads the JSON execution plan and groups its changes by module and provider
+func planReadSummary(client *tfe.Client, planID string) (model.PlanSummary, error) {
+	data, err := planReadJSONOutput(client, planID)
+	if err != nil {
+		return model.PlanSummary{}, fmt.Errorf("unable to read the JSON output of plan %s\n%w", planID, err)
+	}
+
+	planJSON, err := aid.ParsePlanJSON(data)
+	if err != nil {
+		return model.PlanSummary{}, fmt.Errorf("unable to parse the JSON output of plan %s\n%w", planID, err)
+	}
+
+	return aid.GetPlanSummary(planJSON), nil
+}
+
 // StreamToByte converts io.Reader to []byte
 func StreamToByte(stream io.Reader) []byte {
 	buf := new(bytes.Buffer)
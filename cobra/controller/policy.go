@@ -0,0 +1,333 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var policyValidArgs = []string{
+	"list",
+	"create",
+	"read",
+	"update",
+	"delete",
+	"upload",
+	"download"}
+
+// PolicyCmd command to manage the Sentinel and OPA policies of an organization
+func PolicyCmd() *cobra.Command {
+	man, err := helper.GetManual("policy", policyValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    policyValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      policyPreRun,
+		RunE:         policyRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetPolicyFlags(cmd)
+
+	return cmd
+}
+
+func policyPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "policy"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		_, err := aid.GetPolicyKind(cmd)
+		return err
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "name"); err != nil {
+			return err
+		}
+
+		_, err := aid.GetPolicyCreateOptions(cmd)
+		return err
+
+	case "read",
+		"update",
+		"delete",
+		"upload",
+		"download":
+		if cmd.Flags().Changed("id") == cmd.Flags().Changed("name") {
+			return fmt.Errorf("--id or --name is required by policy %s, but not both", fArg)
+		}
+
+		if fArg == "upload" {
+			return helper.ValidateCmdArgAndFlag(cmd, args, "policy", fArg, "file")
+		}
+
+	default:
+		return fmt.Errorf("unknown argument")
+	}
+
+	return nil
+}
+
+func policyRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetOrganizationToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+	organization := dao.GetOrganization(profile)
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		search, err := cmd.Flags().GetString("search")
+		if err != nil {
+			return fmt.Errorf("unable to get flag search\n%w", err)
+		}
+
+		options := tfe.PolicyListOptions{Search: search}
+		if cmd.Flags().Changed("kind") {
+			options.Kind, err = aid.GetPolicyKind(cmd)
+			if err != nil {
+				return err
+			}
+		}
+
+		policies, err := policyListAll(client, organization, options)
+		if err != nil {
+			return fmt.Errorf("unable to list policies\n%w", err)
+		}
+		fmt.Print(aid.RenderPolicies(policies))
+
+	case "create":
+		return policyCreate(cmd, client, organization)
+
+	case "read":
+		policy, err := policyResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+		fmt.Println(aid.ToJSON(policy))
+
+	case "update":
+		policy, err := policyResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
+		options, err := aid.GetPolicyUpdateOptions(cmd, policy.Kind)
+		if err != nil {
+			return err
+		}
+
+		policy, err = client.Policies.Update(context.Background(), policy.ID, options)
+		if err != nil {
+			return fmt.Errorf("unable to update policy\n%w", err)
+		}
+		fmt.Println(aid.ToJSON(policy))
+
+	case "delete":
+		policy, err := policyResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
+		if err := client.Policies.Delete(context.Background(), policy.ID); err != nil {
+			return fmt.Errorf("unable to delete policy %s\n%w", policy.Name, err)
+		}
+		cmd.Printf("policy %s deleted successfully\n", policy.Name)
+
+	case "upload":
+		policy, err := policyResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+		return policyUpload(cmd, client, policy)
+
+	case "download":
+		return policyDownload(cmd, client, organization)
+
+	default:
+		return fmt.Errorf("unknown argument provided")
+	}
+
+	return nil
+}
+
+// policyCreate creates a policy and uploads its code when --file is given. The file is read first,
+// not to leave a policy without code behind.
+func policyCreate(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	options, err := aid.GetPolicyCreateOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("unable to get flag file\n%w", err)
+	}
+
+	var content []byte
+	if file != "" {
+		content, err = os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("unable to read policy file %s\n%w", file, err)
+		}
+	}
+
+	policy, err := client.Policies.Create(context.Background(), organization, options)
+	if err != nil {
+		return fmt.Errorf("unable to create policy\n%w", err)
+	}
+
+	if content != nil {
+		if err := client.Policies.Upload(context.Background(), policy.ID, content); err != nil {
+			return fmt.Errorf("policy %s created, but unable to upload %s, run policy upload to retry\n%w", policy.ID, file, err)
+		}
+	} else {
+		logrus.Warnf("policy %s has no code yet, upload it with: tecli policy upload --id %s --file <file>", policy.Name, policy.ID)
+	}
+
+	fmt.Println(aid.ToJSON(policy))
+	return nil
+}
+
+// policyUpload replaces the code of a policy with the content of --file
+func policyUpload(cmd *cobra.Command, client *tfe.Client, policy *tfe.Policy) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("unable to get flag file\n%w", err)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("unable to read policy file %s\n%w", file, err)
+	}
+
+	if err := client.Policies.Upload(context.Background(), policy.ID, content); err != nil {
+		return fmt.Errorf("unable to upload the code of policy %s\n%w", policy.Name, err)
+	}
+
+	cmd.Printf("%s uploaded to policy %s\n", file, policy.Name)
+	return nil
+}
+
+// policyDownload prints the code of a policy, or writes it to --out
+func policyDownload(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	policy, err := policyResolve(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	content, err := client.Policies.Download(context.Background(), policy.ID)
+	if err != nil {
+		return fmt.Errorf("unable to download the code of policy %s\n%w", policy.Name, err)
+	}
+
+	out, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return fmt.Errorf("unable to get flag out\n%w", err)
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+
+	if err := os.WriteFile(out, content, 0644); err != nil {
+		return fmt.Errorf("unable to write policy to %s\n%w", out, err)
+	}
+
+	logrus.Infof("policy %s written to %s", policy.Name, out)
+	return nil
+}
+
+// policyResolve reads the policy given by --id, or finds the policy given by --name
+func policyResolve(cmd *cobra.Command, client *tfe.Client, organization string) (*tfe.Policy, error) {
+	id, err := cmd.Flags().GetString("id")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag id\n%w", err)
+	}
+
+	if id != "" {
+		policy, err := client.Policies.Read(context.Background(), id)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s not found\n%w", id, err)
+		}
+		return policy, nil
+	}
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+
+	return policyFindByName(client, organization, name)
+}
+
+// policyListAll pages through the policies of an organization
+func policyListAll(client *tfe.Client, organization string, options tfe.PolicyListOptions) ([]*tfe.Policy, error) {
+	var policies []*tfe.Policy
+	options.PageNumber = 1
+	options.PageSize = 100
+	for {
+		list, err := client.Policies.List(context.Background(), organization, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return policies, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
+
+// policyFindByName looks up a policy by exact name, the search matching partial names
+func policyFindByName(client *tfe.Client, organization string, name string) (*tfe.Policy, error) {
+	policies, err := policyListAll(client, organization, tfe.PolicyListOptions{Search: name})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list policies\n%w", err)
+	}
+
+	for _, policy := range policies {
+		if policy.Name == name {
+			return policy, nil
+		}
+	}
+
+	return nil, fmt.Errorf("policy %s not found\n%w", name, tfe.ErrResourceNotFound)
+}
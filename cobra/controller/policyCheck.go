@@ -0,0 +1,241 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var policyCheckValidArgs = []string{"list", "logs", "override"}
+
+// PolicyCheckCmd command to list, read the logs of and override policy checks
+func PolicyCheckCmd() *cobra.Command {
+	man, err := helper.GetManual("policy-check", policyCheckValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    policyCheckValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      policyCheckPreRun,
+		RunE:         policyCheckRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetPolicyCheckFlags(cmd)
+
+	return cmd
+}
+
+func policyCheckPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "policy-check"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-check", fArg, "run-id"); err != nil {
+			return err
+		}
+	case "logs":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-check", fArg, "id"); err != nil {
+			return err
+		}
+	case "override":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-check", fArg, "id"); err != nil {
+			return err
+		}
+
+		reason, err := cmd.Flags().GetString("reason")
+		if err != nil {
+			return fmt.Errorf("unable to get flag reason\n%w", err)
+		}
+
+		if strings.TrimSpace(reason) == "" {
+			return fmt.Errorf("--reason is required to override a policy check")
+		}
+	}
+
+	if fArg != "override" && cmd.Flags().Changed("reason") {
+		return fmt.Errorf("--reason can only be used with policy-check override")
+	}
+
+	if fArg == "logs" && cmd.Flags().Changed("format") {
+		return fmt.Errorf("--format can only be used with policy-check list and override")
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	if !helper.ContainsString(aid.PolicyCheckFormats, format) {
+		return fmt.Errorf("invalid --format %q, valid values are: %s", format, strings.Join(aid.PolicyCheckFormats, ", "))
+	}
+
+	return nil
+}
+
+func policyCheckRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		runID, err := cmd.Flags().GetString("run-id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag run-id\n%w", err)
+		}
+
+		checks, err := policyCheckListAll(client, runID)
+		if err != nil {
+			return fmt.Errorf("unable to list the policy checks of run %s\n%w", runID, err)
+		}
+
+		if len(checks) == 0 {
+			fmt.Printf("run %s has no policy check\n", runID)
+			return nil
+		}
+
+		return policyCheckPrint(cmd, checks)
+	case "logs":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		logs, err := policyCheckLogs(client, id)
+		if err != nil {
+			return fmt.Errorf("unable to read policy check logs\n%w", err)
+		}
+
+		if _, err := io.Copy(os.Stdout, logs); err != nil {
+			return fmt.Errorf("unable to read policy check logs\n%w", err)
+		}
+	case "override":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		reason, err := cmd.Flags().GetString("reason")
+		if err != nil {
+			return fmt.Errorf("unable to get flag reason\n%w", err)
+		}
+
+		pc, err := policyCheckOverride(client, id, reason)
+		if err != nil {
+			return err
+		}
+
+		return policyCheckPrint(cmd, []*tfe.PolicyCheck{pc})
+	}
+
+	return nil
+}
+
+// policyCheckPrint prints the policy checks in the format given by --format
+func policyCheckPrint(cmd *cobra.Command, checks []*tfe.PolicyCheck) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	if format == "json" {
+		fmt.Println(aid.ToJSON(checks))
+		return nil
+	}
+
+	out, err := aid.RenderPolicyChecks(checks)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+
+	return nil
+}
+
+// policyCheckListAll pages through the policy checks of a run
+func policyCheckListAll(client *tfe.Client, runID string) ([]*tfe.PolicyCheck, error) {
+	var checks []*tfe.PolicyCheck
+	options := tfe.PolicyCheckListOptions{ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100}}
+	for {
+		list, err := client.PolicyChecks.List(context.Background(), runID, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return checks, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
+
+// Logs retrieves the Sentinel output of a policy check, once it's finished.
+func policyCheckLogs(client *tfe.Client, policyCheckID string) (io.Reader, error) {
+	return client.PolicyChecks.Logs(context.Background(), policyCheckID)
+}
+
+// policyCheckOverride overrides a soft-failed policy check and posts the reason as a comment on its run
+func policyCheckOverride(client *tfe.Client, policyCheckID string, reason string) (*tfe.PolicyCheck, error) {
+	pc, err := client.PolicyChecks.Read(context.Background(), policyCheckID)
+	if err != nil {
+		return nil, fmt.Errorf("policy check %s not found\n%w", policyCheckID, err)
+	}
+
+	if pc.Actions == nil || !pc.Actions.IsOverridable {
+		return nil, fmt.Errorf("policy check %s is %s, only a soft-failed policy check can be overridden", policyCheckID, pc.Status)
+	}
+
+	overridden, err := client.PolicyChecks.Override(context.Background(), policyCheckID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to override policy check %s\n%w", policyCheckID, err)
+	}
+
+	if pc.Run != nil {
+		body := fmt.Sprintf("Policy check %s overridden: %s", policyCheckID, reason)
+		if _, err := client.Comments.Create(context.Background(), pc.Run.ID, tfe.CommentCreateOptions{Body: body}); err != nil {
+			// the override already happened, the reason is still in the audit journal
+			logrus.Warnf("unable to comment the override reason on run %s\n%v", pc.Run.ID, err)
+		}
+	}
+
+	return overridden, nil
+}
@@ -0,0 +1,547 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var policySetValidArgs = []string{
+	"list",
+	"create",
+	"read",
+	"update",
+	"delete",
+	"add-workspace",
+	"remove-workspace",
+	"add-policy",
+	"remove-policy",
+	"list-parameters",
+	"set-parameter",
+	"delete-parameter",
+	"upload"}
+
+// policySetVersionInterval is how often an uploaded policy set version is read until it is ingested
+const policySetVersionInterval = 2 * time.Second
+
+// PolicySetCmd command to manage the policy sets of an organization
+func PolicySetCmd() *cobra.Command {
+	man, err := helper.GetManual("policy-set", policySetValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    policySetValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      policySetPreRun,
+		RunE:         policySetRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetPolicySetFlags(cmd)
+
+	return cmd
+}
+
+func policySetPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "policy-set"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		_, err := aid.GetPolicyKind(cmd)
+		return err
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-set", fArg, "name"); err != nil {
+			return err
+		}
+
+		_, err := aid.GetPolicySetCreateOptions(cmd)
+		return err
+
+	case "read",
+		"update",
+		"delete",
+		"add-workspace",
+		"remove-workspace",
+		"add-policy",
+		"remove-policy",
+		"list-parameters",
+		"set-parameter",
+		"delete-parameter",
+		"upload":
+		if cmd.Flags().Changed("id") == cmd.Flags().Changed("name") {
+			return fmt.Errorf("--id or --name is required by policy-set %s, but not both", fArg)
+		}
+
+		switch fArg {
+		case "add-workspace", "remove-workspace":
+			if !cmd.Flags().Changed("workspace") {
+				return fmt.Errorf("--workspace must be defined")
+			}
+		case "add-policy", "remove-policy":
+			if !cmd.Flags().Changed("policy") {
+				return fmt.Errorf("--policy must be defined")
+			}
+		case "set-parameter", "delete-parameter":
+			return helper.ValidateCmdArgAndFlag(cmd, args, "policy-set", fArg, "key")
+		case "upload":
+			if err := helper.ValidateCmdArgAndFlag(cmd, args, "policy-set", fArg, "dir"); err != nil {
+				return err
+			}
+			_, err := aid.GetPolicySetDir(cmd)
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown argument")
+	}
+
+	return nil
+}
+
+func policySetRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetOrganizationToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+	organization := dao.GetOrganization(profile)
+
+	fArg := args[0]
+	if fArg == "list" {
+		return policySetList(cmd, client, organization)
+	}
+	if fArg == "create" {
+		return policySetCreate(cmd, client, organization)
+	}
+
+	ps, err := policySetResolve(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	switch fArg {
+	case "read":
+		ps, err = client.PolicySets.ReadWithOptions(context.Background(), ps.ID, &tfe.PolicySetReadOptions{Include: []tfe.PolicySetIncludeOpt{tfe.PolicySetPolicies, tfe.PolicySetWorkspaces, tfe.PolicySetCurrentVersion}})
+		if err != nil {
+			return fmt.Errorf("unable to read policy set %s\n%w", ps.ID, err)
+		}
+		fmt.Println(aid.ToJSON(ps))
+
+	case "update":
+		options, err := aid.GetPolicySetUpdateOptions(cmd, ps.Kind)
+		if err != nil {
+			return err
+		}
+
+		ps, err = client.PolicySets.Update(context.Background(), ps.ID, options)
+		if err != nil {
+			return fmt.Errorf("unable to update policy set\n%w", err)
+		}
+		fmt.Println(aid.ToJSON(ps))
+
+	case "delete":
+		if err := client.PolicySets.Delete(context.Background(), ps.ID); err != nil {
+			return fmt.Errorf("unable to delete policy set %s\n%w", ps.Name, err)
+		}
+		cmd.Printf("policy set %s deleted successfully\n", ps.Name)
+
+	case "add-workspace", "remove-workspace":
+		return policySetWorkspaces(cmd, client, organization, ps, fArg)
+
+	case "add-policy", "remove-policy":
+		return policySetPolicies(cmd, client, organization, ps, fArg)
+
+	case "list-parameters":
+		parameters, err := policySetParameterListAll(client, ps.ID)
+		if err != nil {
+			return fmt.Errorf("unable to list the parameters of policy set %s\n%w", ps.Name, err)
+		}
+		fmt.Print(aid.RenderPolicySetParameters(parameters))
+
+	case "set-parameter":
+		return policySetParameterSet(cmd, client, ps)
+
+	case "delete-parameter":
+		return policySetParameterDelete(cmd, client, ps)
+
+	case "upload":
+		return policySetUpload(cmd, client, ps)
+
+	default:
+		return fmt.Errorf("unknown argument provided")
+	}
+
+	return nil
+}
+
+func policySetList(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	search, err := cmd.Flags().GetString("search")
+	if err != nil {
+		return fmt.Errorf("unable to get flag search\n%w", err)
+	}
+
+	options := tfe.PolicySetListOptions{Search: search}
+	if cmd.Flags().Changed("kind") {
+		options.Kind, err = aid.GetPolicyKind(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	sets, err := policySetListAll(client, organization, options)
+	if err != nil {
+		return fmt.Errorf("unable to list policy sets\n%w", err)
+	}
+
+	fmt.Print(aid.RenderPolicySets(sets))
+	return nil
+}
+
+// policySetCreate creates a policy set with the workspaces and policies given by name
+func policySetCreate(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	options, err := aid.GetPolicySetCreateOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("workspace") {
+		options.Workspaces, err = teamAccessWorkspaces(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("policy") {
+		options.Policies, err = policySetPolicyList(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+	}
+
+	ps, err := client.PolicySets.Create(context.Background(), organization, options)
+	if err != nil {
+		return fmt.Errorf("unable to create policy set\n%w", err)
+	}
+
+	fmt.Println(aid.ToJSON(ps))
+	return nil
+}
+
+// policySetWorkspaces adds the workspaces given by --workspace to the policy set, or removes them
+func policySetWorkspaces(cmd *cobra.Command, client *tfe.Client, organization string, ps *tfe.PolicySet, action string) error {
+	if ps.Global {
+		return fmt.Errorf("policy set %s is global, it applies to every workspace", ps.Name)
+	}
+
+	workspaces, err := teamAccessWorkspaces(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, workspace := range workspaces {
+		names = append(names, workspace.Name)
+	}
+
+	if action == "add-workspace" {
+		err = client.PolicySets.AddWorkspaces(context.Background(), ps.ID, tfe.PolicySetAddWorkspacesOptions{Workspaces: workspaces})
+		if err != nil {
+			return fmt.Errorf("unable to add %s to policy set %s\n%w", strings.Join(names, ", "), ps.Name, err)
+		}
+		cmd.Printf("%s added to policy set %s\n", strings.Join(names, ", "), ps.Name)
+		return nil
+	}
+
+	err = client.PolicySets.RemoveWorkspaces(context.Background(), ps.ID, tfe.PolicySetRemoveWorkspacesOptions{Workspaces: workspaces})
+	if err != nil {
+		return fmt.Errorf("unable to remove %s from policy set %s\n%w", strings.Join(names, ", "), ps.Name, err)
+	}
+	cmd.Printf("%s removed from policy set %s\n", strings.Join(names, ", "), ps.Name)
+	return nil
+}
+
+// policySetPolicies adds the policies given by --policy to the policy set, or removes them
+func policySetPolicies(cmd *cobra.Command, client *tfe.Client, organization string, ps *tfe.PolicySet, action string) error {
+	if ps.VCSRepo != nil {
+		return fmt.Errorf("policy set %s is VCS-backed, its policies come from %s", ps.Name, ps.VCSRepo.Identifier)
+	}
+
+	policies, err := policySetPolicyList(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+	}
+
+	if action == "add-policy" {
+		err = client.PolicySets.AddPolicies(context.Background(), ps.ID, tfe.PolicySetAddPoliciesOptions{Policies: policies})
+		if err != nil {
+			return fmt.Errorf("unable to add %s to policy set %s\n%w", strings.Join(names, ", "), ps.Name, err)
+		}
+		cmd.Printf("%s added to policy set %s\n", strings.Join(names, ", "), ps.Name)
+		return nil
+	}
+
+	err = client.PolicySets.RemovePolicies(context.Background(), ps.ID, tfe.PolicySetRemovePoliciesOptions{Policies: policies})
+	if err != nil {
+		return fmt.Errorf("unable to remove %s from policy set %s\n%w", strings.Join(names, ", "), ps.Name, err)
+	}
+	cmd.Printf("%s removed from policy set %s\n", strings.Join(names, ", "), ps.Name)
+	return nil
+}
+
+// policySetPolicyList returns the policies given by --policy
+func policySetPolicyList(cmd *cobra.Command, client *tfe.Client, organization string) ([]*tfe.Policy, error) {
+	names, err := cmd.Flags().GetStringSlice("policy")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag policy\n%w", err)
+	}
+
+	var policies []*tfe.Policy
+	for _, name := range names {
+		policy, err := policyFindByName(client, organization, name)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// policySetParameterSet creates the parameter given by --key, or updates it when it exists
+func policySetParameterSet(cmd *cobra.Command, client *tfe.Client, ps *tfe.PolicySet) error {
+	key, err := cmd.Flags().GetString("key")
+	if err != nil {
+		return fmt.Errorf("unable to get flag key\n%w", err)
+	}
+
+	value, err := cmd.Flags().GetString("value")
+	if err != nil {
+		return fmt.Errorf("unable to get flag value\n%w", err)
+	}
+
+	sensitive, err := cmd.Flags().GetBool("sensitive")
+	if err != nil {
+		return fmt.Errorf("unable to get flag sensitive\n%w", err)
+	}
+
+	current, err := policySetParameterFind(client, ps, key)
+	if err != nil && !errors.Is(err, tfe.ErrResourceNotFound) {
+		return err
+	}
+
+	if current == nil {
+		category := tfe.CategoryPolicySet
+		options := tfe.PolicySetParameterCreateOptions{Key: &key, Value: &value, Category: &category, Sensitive: &sensitive}
+		p, err := client.PolicySetParameters.Create(context.Background(), ps.ID, options)
+		if err != nil {
+			return fmt.Errorf("unable to create parameter %s of policy set %s\n%w", key, ps.Name, err)
+		}
+		cmd.Printf("parameter %s (%s) created on policy set %s\n", key, p.ID, ps.Name)
+		return nil
+	}
+
+	options := tfe.PolicySetParameterUpdateOptions{Value: &value}
+	if cmd.Flags().Changed("sensitive") {
+		options.Sensitive = &sensitive
+	}
+
+	if _, err := client.PolicySetParameters.Update(context.Background(), ps.ID, current.ID, options); err != nil {
+		return fmt.Errorf("unable to update parameter %s of policy set %s\n%w", key, ps.Name, err)
+	}
+	cmd.Printf("parameter %s (%s) updated on policy set %s\n", key, current.ID, ps.Name)
+	return nil
+}
+
+func policySetParameterDelete(cmd *cobra.Command, client *tfe.Client, ps *tfe.PolicySet) error {
+	key, err := cmd.Flags().GetString("key")
+	if err != nil {
+		return fmt.Errorf("unable to get flag key\n%w", err)
+	}
+
+	current, err := policySetParameterFind(client, ps, key)
+	if err != nil {
+		return err
+	}
+
+	if err := client.PolicySetParameters.Delete(context.Background(), ps.ID, current.ID); err != nil {
+		return fmt.Errorf("unable to delete parameter %s of policy set %s\n%w", key, ps.Name, err)
+	}
+	cmd.Printf("parameter %s deleted from policy set %s\n", key, ps.Name)
+	return nil
+}
+
+// policySetParameterFind returns the parameter of a policy set with the given key
+func policySetParameterFind(client *tfe.Client, ps *tfe.PolicySet, key string) (*tfe.PolicySetParameter, error) {
+	parameters, err := policySetParameterListAll(client, ps.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the parameters of policy set %s\n%w", ps.Name, err)
+	}
+
+	for _, p := range parameters {
+		if p.Key == key {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("policy set %s has no parameter %s\n%w", ps.Name, key, tfe.ErrResourceNotFound)
+}
+
+// policySetUpload publishes the directory given by --dir as a new version of the policy set, and waits
+// until the version is ingested
+func policySetUpload(cmd *cobra.Command, client *tfe.Client, ps *tfe.PolicySet) error {
+	if ps.VCSRepo != nil {
+		return fmt.Errorf("policy set %s is VCS-backed, its versions are ingested from %s", ps.Name, ps.VCSRepo.Identifier)
+	}
+
+	dir, err := aid.GetPolicySetDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return fmt.Errorf("unable to get flag timeout\n%w", err)
+	}
+
+	psv, err := client.PolicySetVersions.Create(context.Background(), ps.ID)
+	if err != nil {
+		return fmt.Errorf("unable to create a version of policy set %s\n%w", ps.Name, err)
+	}
+
+	if err := client.PolicySetVersions.Upload(context.Background(), *psv, dir); err != nil {
+		return fmt.Errorf("unable to upload %s to policy set version %s\n%w", dir, psv.ID, err)
+	}
+	logrus.Infof("%s uploaded to policy set version %s, waiting for it to be ingested", dir, psv.ID)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		psv, err = client.PolicySetVersions.Read(context.Background(), psv.ID)
+		if err != nil {
+			return fmt.Errorf("unable to read policy set version\n%w", err)
+		}
+
+		switch psv.Status {
+		case tfe.PolicySetVersionReady:
+			cmd.Printf("policy set version %s of %s is ready\n", psv.ID, ps.Name)
+			return nil
+		case tfe.PolicySetVersionErrored:
+			return fmt.Errorf("policy set version %s errored\n%s", psv.ID, psv.ErrorMessage)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("policy set version %s is still %s after %s", psv.ID, psv.Status, timeout)
+		}
+
+		time.Sleep(policySetVersionInterval)
+	}
+}
+
+// policySetResolve reads the policy set given by --id, or finds the policy set given by --name
+func policySetResolve(cmd *cobra.Command, client *tfe.Client, organization string) (*tfe.PolicySet, error) {
+	id, err := cmd.Flags().GetString("id")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag id\n%w", err)
+	}
+
+	if id != "" {
+		ps, err := client.PolicySets.Read(context.Background(), id)
+		if err != nil {
+			return nil, fmt.Errorf("policy set %s not found\n%w", id, err)
+		}
+		return ps, nil
+	}
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+
+	sets, err := policySetListAll(client, organization, tfe.PolicySetListOptions{Search: name})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list policy sets\n%w", err)
+	}
+
+	for _, ps := range sets {
+		if ps.Name == name {
+			return ps, nil
+		}
+	}
+
+	return nil, fmt.Errorf("policy set %s not found\n%w", name, tfe.ErrResourceNotFound)
+}
+
+// policySetListAll pages through the policy sets of an organization
+func policySetListAll(client *tfe.Client, organization string, options tfe.PolicySetListOptions) ([]*tfe.PolicySet, error) {
+	var sets []*tfe.PolicySet
+	options.PageNumber = 1
+	options.PageSize = 100
+	for {
+		list, err := client.PolicySets.List(context.Background(), organization, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return sets, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
+
+func policySetParameterListAll(client *tfe.Client, policySetID string) ([]*tfe.PolicySetParameter, error) {
+	var parameters []*tfe.PolicySetParameter
+	options := tfe.PolicySetParameterListOptions{ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100}}
+	for {
+		list, err := client.PolicySetParameters.List(context.Background(), policySetID, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		parameters = append(parameters, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return parameters, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var projectValidArgs = []string{
+	"list",
+	"create",
+	"read",
+	"update",
+	"delete"}
+
+// ProjectCmd command to manage the projects of an organization
+func ProjectCmd() *cobra.Command {
+	man, err := helper.GetManual("project", projectValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    projectValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      projectPreRun,
+		RunE:         projectRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetProjectFlags(cmd)
+
+	return cmd
+}
+
+func projectPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "project"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		// skipping...
+		return nil
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "project", fArg, "name"); err != nil {
+			return err
+		}
+
+	case "read",
+		"update",
+		"delete":
+		if cmd.Flags().Changed("id") == cmd.Flags().Changed("name") {
+			return fmt.Errorf("--id or --name is required by project %s, but not both", fArg)
+		}
+
+	default:
+		return fmt.Errorf("unknown argument")
+	}
+
+	return nil
+}
+
+func projectRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetOrganizationToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+	organization := dao.GetOrganization(profile)
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		search, err := cmd.Flags().GetString("search")
+		if err != nil {
+			return fmt.Errorf("unable to get flag search\n%w", err)
+		}
+
+		projects, err := projectListAll(client, organization, tfe.ProjectListOptions{Query: search})
+		if err != nil {
+			return fmt.Errorf("unable to list projects\n%w", err)
+		}
+		fmt.Print(aid.RenderProjects(projects))
+
+	case "create":
+		options, err := aid.GetProjectCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		project, err := client.Projects.Create(context.Background(), organization, options)
+		if err != nil {
+			return fmt.Errorf("unable to create project\n%w", err)
+		}
+		fmt.Println(aid.ToJSON(project))
+
+	case "read":
+		project, err := projectResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+		fmt.Println(aid.ToJSON(project))
+
+	case "update":
+		options, err := aid.GetProjectUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		project, err := projectResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
+		project, err = client.Projects.Update(context.Background(), project.ID, options)
+		if err != nil {
+			return fmt.Errorf("unable to update project\n%w", err)
+		}
+		fmt.Println(aid.ToJSON(project))
+
+	case "delete":
+		project, err := projectResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
+		// a project holding workspaces can't be deleted
+		if err := client.Projects.Delete(context.Background(), project.ID); err != nil {
+			return fmt.Errorf("unable to delete project %s, move its workspaces to another project first\n%w", project.Name, err)
+		}
+		cmd.Printf("project %s deleted successfully\n", project.Name)
+
+	default:
+		return fmt.Errorf("unknown argument provided")
+	}
+
+	return nil
+}
+
+// projectResolve reads the project given by --id, or finds the project given by --name
+func projectResolve(cmd *cobra.Command, client *tfe.Client, organization string) (*tfe.Project, error) {
+	id, err := cmd.Flags().GetString("id")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag id\n%w", err)
+	}
+
+	if id != "" {
+		project, err := client.Projects.Read(context.Background(), id)
+		if err != nil {
+			return nil, fmt.Errorf("project %s not found\n%w", id, err)
+		}
+		return project, nil
+	}
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+
+	return projectFindByName(client, organization, name)
+}
+
+// projectFlag returns the project given by --project, a project name or ID, nil when not given
+func projectFlag(cmd *cobra.Command, client *tfe.Client, organization string) (*tfe.Project, error) {
+	if !cmd.Flags().Changed("project") {
+		return nil, nil
+	}
+
+	value, err := cmd.Flags().GetString("project")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag project\n%w", err)
+	}
+
+	if aid.IsProjectID(value) {
+		return &tfe.Project{ID: value}, nil
+	}
+
+	return projectFindByName(client, organization, value)
+}
+
+// projectListAll pages through the projects of an organization
+func projectListAll(client *tfe.Client, organization string, options tfe.ProjectListOptions) ([]*tfe.Project, error) {
+	var projects []*tfe.Project
+	options.PageNumber = 1
+	options.PageSize = 100
+	for {
+		list, err := client.Projects.List(context.Background(), organization, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return projects, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
+
+// projectFindByName looks up a project by exact name, the names filter matching names exactly
+func projectFindByName(client *tfe.Client, organization string, name string) (*tfe.Project, error) {
+	projects, err := projectListAll(client, organization, tfe.ProjectListOptions{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list projects\n%w", err)
+	}
+
+	for _, project := range projects {
+		if project.Name == name {
+			return project, nil
+		}
+	}
+
+	return nil, fmt.Errorf("project %s not found\n%w", name, tfe.ErrResourceNotFound)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var overrideProtection string
+
+// protectedOperation describes a destructive operation guarded by the
+// profile's protected workspace patterns
+type protectedOperation struct {
+	command  string
+	argument string
+	// applies reports whether the invocation is destructive, nil means always
+	applies func(cmd *cobra.Command) bool
+	// workspace resolves the name of the workspace targeted by the invocation
+	workspace func(cmd *cobra.Command) (string, error)
+}
+
+var protectedOperations = []protectedOperation{
+	{command: "run", argument: "apply", workspace: workspaceNameFromRunFlag("id")},
+	{command: "run", argument: "create", applies: anyFlagIsTrue("is-destroy", "auto-apply", "allow-empty-apply"), workspace: workspaceNameFromIDFlag("workspace-id")},
+	{command: "workspace", argument: "delete", workspace: workspaceNameFromFlag("name")},
+	{command: "workspace", argument: "delete-by-id", workspace: workspaceNameFromIDFlag("id")},
+	{command: "workspace", argument: "update", applies: flagIsTrue("auto-apply"), workspace: workspaceNameFromFlag("name")},
+	{command: "workspace", argument: "update-by-id", applies: flagIsTrue("auto-apply"), workspace: workspaceNameFromIDFlag("id")},
+	{command: "variable", argument: "delete-all", workspace: workspaceNameFromIDFlag("workspace-id")},
+	{command: "state", argument: "push", workspace: workspaceNameFromFlag("workspace")},
+}
+
+// protectionPreRun refuses destructive operations against protected workspaces
+// unless --override-protection is given, in which case the reason is audited
+func protectionPreRun(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 || !IsProtectedOperation(cmd, args[0]) {
+		return nil
+	}
+	op, _ := findProtectedOperation(cmd.Name(), args[0])
+
+	// resolving the workspace may call the API, so it's skipped when nothing is protected
+	if len(dao.GetProtectedPatterns(profile)) == 0 {
+		return nil
+	}
+
+	name, err := op.workspace(cmd)
+	if err != nil {
+		return fmt.Errorf("unable to verify workspace protection\n%w", err)
+	}
+
+	return checkProtectedWorkspaces([]string{name})
+}
+
+// checkProtectedWorkspaces refuses an operation on the given workspaces when any of them is
+// protected, unless --override-protection is given, in which case the reason is audited
+func checkProtectedWorkspaces(names []string) error {
+	patterns := dao.GetProtectedPatterns(profile)
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var protected, resources []string
+	for _, name := range names {
+		pattern, found := aid.MatchProtectedPattern(patterns, name)
+		if found {
+			protected = append(protected, fmt.Sprintf("workspace %s is protected by pattern %q", name, pattern))
+			resources = append(resources, "workspace="+name)
+		}
+	}
+
+	if len(protected) == 0 {
+		return nil
+	}
+
+	if overrideProtection == "" {
+		return fmt.Errorf("%s\nuse --override-protection \"<reason>\" to proceed", strings.Join(protected, "\n"))
+	}
+
+	for _, resource := range resources {
+		logrus.Warnf("overriding protection of workspace %s: %s", strings.TrimPrefix(resource, "workspace="), overrideProtection)
+	}
+
+	return recordAudit(model.AuditEntry{
+		Command:   strings.Join(aid.RedactArgs(append([]string{"tecli"}, os.Args[1:]...)), " "),
+		Resources: resources,
+		Result:    "protection-override",
+		Reason:    overrideProtection,
+	})
+}
+
+// IsProtectedOperation tells whether the given invocation is destructive, and so
+// guarded by the protected workspace patterns of the profile
+func IsProtectedOperation(cmd *cobra.Command, argument string) bool {
+	op, found := findProtectedOperation(cmd.Name(), argument)
+	return found && (op.applies == nil || op.applies(cmd))
+}
+
+func findProtectedOperation(command string, argument string) (protectedOperation, bool) {
+	for _, op := range protectedOperations {
+		if op.command == command && op.argument == argument {
+			return op, true
+		}
+	}
+
+	return protectedOperation{}, false
+}
+
+func flagIsTrue(flag string) func(cmd *cobra.Command) bool {
+	return func(cmd *cobra.Command) bool {
+		if !cmd.Flags().Changed(flag) {
+			return false
+		}
+
+		// an unreadable flag is treated as destructive
+		value, err := cmd.Flags().GetBool(flag)
+		return err != nil || value
+	}
+}
+
+// anyFlagIsTrue is like flagIsTrue, for invocations made destructive by any of the given flags
+func anyFlagIsTrue(flags ...string) func(cmd *cobra.Command) bool {
+	return func(cmd *cobra.Command) bool {
+		for _, flag := range flags {
+			if flagIsTrue(flag)(cmd) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func workspaceNameFromFlag(flag string) func(cmd *cobra.Command) (string, error) {
+	return func(cmd *cobra.Command) (string, error) {
+		return cmd.Flags().GetString(flag)
+	}
+}
+
+func workspaceNameFromIDFlag(flag string) func(cmd *cobra.Command) (string, error) {
+	return func(cmd *cobra.Command) (string, error) {
+		id, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return "", fmt.Errorf("unable to get flag %s\n%w", flag, err)
+		}
+
+		client, err := aid.GetTFEClient(dao.GetOrganizationToken(profile))
+		if err != nil {
+			return "", err
+		}
+
+		workspace, err := workspaceReadByID(client, id)
+		if err != nil {
+			return "", fmt.Errorf("unable to find workspace %s\n%w", id, err)
+		}
+
+		return workspace.Name, nil
+	}
+}
+
+func workspaceNameFromRunFlag(flag string) func(cmd *cobra.Command) (string, error) {
+	return func(cmd *cobra.Command) (string, error) {
+		id, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return "", fmt.Errorf("unable to get flag %s\n%w", flag, err)
+		}
+
+		client, err := aid.GetTFEClient(dao.GetTeamToken(profile))
+		if err != nil {
+			return "", err
+		}
+
+		run, err := client.Runs.ReadWithOptions(context.Background(), id, &tfe.RunReadOptions{
+			Include: []tfe.RunIncludeOpt{tfe.RunWorkspace},
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to find run %s\n%w", id, err)
+		}
+
+		if run.Workspace == nil {
+			return "", fmt.Errorf("run %s has no workspace", id)
+		}
+
+		return run.Workspace.Name, nil
+	}
+}
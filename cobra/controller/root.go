@@ -16,36 +16,84 @@ limitations under the License.
 package controller
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/helper"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 var profile string
 
+var (
+	logLevel  string
+	logFile   string
+	debugHTTP bool
+)
+
 // RootCmd represents the base command when called without any subcommands
 func RootCmd() *cobra.Command {
 	man, err := helper.GetManual("root", []string{})
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
-		Short: man.Short,
-		Long:  man.Long,
+		Short:             man.Short,
+		Long:              man.Long,
+		PersistentPreRunE: rootPersistentPreRun,
+		// errors are printed by ReportError, honoring --error-format
+		SilenceErrors: true,
 	}
 
 	cmd.PersistentFlags().StringVarP(&profile, "profile", "p", "default", "Use a specific profile from your credentials and configurations file.")
+	cmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level. Valid values: panic, fatal, error, warn, info, debug or trace.")
+	cmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write logs as JSON to the given file instead of stderr.")
+	cmd.PersistentFlags().BoolVar(&debugHTTP, "debug-http", false, "Log every API request and response (method, URL, status, latency and JSON body) with credentials and sensitive values redacted.")
+	cmd.PersistentFlags().BoolVar(&debugHTTP, "verbose", false, "Alias of --debug-http.")
+	cmd.PersistentFlags().StringVar(&errorFormat, "error-format", "text", "Format of the errors printed on stderr. Valid values: text or json.")
+	cmd.PersistentFlags().StringVar(&overrideProtection, "override-protection", "", "Allow a destructive operation on a protected workspace. The given reason is recorded in the audit log.")
 
 	return cmd
 }
 
+// rootPersistentPreRun runs before every command, regardless of the command's own PreRunE
+func rootPersistentPreRun(cmd *cobra.Command, args []string) error {
+	runningCmd = cmd
+
+	if err := validateErrorFormat(); err != nil {
+		return err
+	}
+
+	if err := loggingPreRun(); err != nil {
+		return err
+	}
+
+	return protectionPreRun(cmd, args)
+}
+
+// loggingPreRun applies the logging flags
+func loggingPreRun() error {
+	if err := aid.SetupLoggingLevel(logLevel); err != nil {
+		return err
+	}
+
+	if logFile != "" {
+		if err := aid.SetupLoggingOutput(logFile); err != nil {
+			return err
+		}
+	}
+
+	if debugHTTP && !logrus.IsLevelEnabled(logrus.DebugLevel) {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+	aid.SetDebugHTTP(debugHTTP)
+
+	return nil
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
+	logrus.AddHook(&auditFatalHook{})
 }
 
 func initConfig() {
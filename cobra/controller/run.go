@@ -18,12 +18,15 @@ package controller
 import (
 	"context"
 	"fmt"
-	"os"
+	"strings"
+	"time"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/cobra/model"
 	"github.com/awslabs/tecli/helper"
 	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -38,14 +41,16 @@ var runValidArgs = []string{
 	"force-cancel",
 	"force-cancel-all",
 	"discard",
-	"discard-all"}
+	"discard-all",
+	"report",
+	"ps",
+	"why"}
 
 // RunCmd command to display tecli current version
 func RunCmd() *cobra.Command {
 	man, err := helper.GetManual("run", runValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -55,8 +60,8 @@ func RunCmd() *cobra.Command {
 		Example:      man.Example,
 		ValidArgs:    runValidArgs,
 		Args:         cobra.OnlyValidArgs,
-		PreRunE:      runPreRun,
-		RunE:         runRun,
+		PreRunE:      withDetailedExitCode(runPreRun),
+		RunE:         withDetailedExitCode(runRun),
 		SilenceUsage: true,
 	}
 
@@ -65,6 +70,30 @@ func RunCmd() *cobra.Command {
 	return cmd
 }
 
+// runListFlags are the filters of run list, they also select the runs of cancel-all, force-cancel-all and discard-all
+var runListFlags = []string{"status", "operation", "source", "commit", "user", "since", "until", "sort", "limit"}
+
+// runCreateFlags are the flags of create, other than is-destroy, message and target-addrs
+var runCreateFlags = []string{
+	"wait", "detailed-exitcode", "discard-if-no-changes", "poll-interval", "timeout",
+	"refresh-only", "refresh", "replace-addrs", "plan-only", "allow-empty-apply", "auto-apply", "terraform-version", "var",
+}
+
+// runBulkFlags are the flags of cancel-all, force-cancel-all and discard-all
+var runBulkFlags = append([]string{"message-regex", "concurrency"}, runListFlags...)
+
+// runArgumentFlags are the flags that only apply to some arguments of run
+var runArgumentFlags = map[string][]string{
+	"list":             append([]string{"with-cost"}, runListFlags...),
+	"create":           runCreateFlags,
+	"apply":            {"if-no-destroy", "max-changes", "deny-addrs", "expected-commit-sha", "max-delta"},
+	"report":           {"format", "template"},
+	"ps":               {"watch", "poll-interval", "concurrency"},
+	"cancel-all":       runBulkFlags,
+	"force-cancel-all": runBulkFlags,
+	"discard-all":      runBulkFlags,
+}
+
 func runPreRun(cmd *cobra.Command, args []string) error {
 	if err := helper.ValidateCmdArgs(cmd, args, "run"); err != nil {
 		return err
@@ -72,11 +101,192 @@ func runPreRun(cmd *cobra.Command, args []string) error {
 
 	fArg := args[0]
 	switch fArg {
-	case "list", "create":
+	case "list", "create", "cancel-all", "force-cancel-all", "discard-all":
 		if err := helper.ValidateCmdArgAndFlag(cmd, args, "run", fArg, "workspace-id"); err != nil {
 			return err
 		}
+	case "report", "why":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "run", fArg, "id"); err != nil {
+			return err
+		}
+	}
+
+	if err := runArgumentFlagsPreRun(cmd, fArg); err != nil {
+		return err
+	}
+
+	switch fArg {
+	case "list":
+		return runListPreRun(cmd)
+	case "cancel-all", "force-cancel-all", "discard-all":
+		if err := runListPreRun(cmd); err != nil {
+			return err
+		}
+		return runBulkPreRun(cmd)
+	case "create":
+		if err := runCreatePreRun(cmd); err != nil {
+			return err
+		}
+		return runWaitPreRun(cmd)
+	case "apply":
+		return runApplyPreRun(cmd)
+	case "report":
+		return runReportPreRun(cmd)
+	case "ps":
+		return runPsPreRun(cmd)
+	}
+
+	return nil
+}
+
+// runBulkPreRun checks the flags of cancel-all, force-cancel-all and discard-all
+func runBulkPreRun(cmd *cobra.Command) error {
+	if _, err := aid.GetRunMessageRegex(cmd); err != nil {
+		return err
+	}
+
+	return runConcurrencyPreRun(cmd)
+}
+
+// runConcurrencyPreRun checks the value of --concurrency
+func runConcurrencyPreRun(cmd *cobra.Command) error {
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return fmt.Errorf("unable to get flag concurrency\n%w", err)
+	}
+
+	if concurrency < 1 {
+		return fmt.Errorf("invalid --concurrency %d, it must be at least 1", concurrency)
+	}
+
+	return nil
+}
+
+// runPsPreRun checks the flags of run ps
+func runPsPreRun(cmd *cobra.Command) error {
+	if err := runConcurrencyPreRun(cmd); err != nil {
+		return err
+	}
+
+	interval, err := cmd.Flags().GetDuration("poll-interval")
+	if err != nil {
+		return fmt.Errorf("unable to get flag poll-interval\n%w", err)
+	}
+
+	if interval <= 0 {
+		return fmt.Errorf("invalid --poll-interval %s, it must be greater than zero", interval)
+	}
+
+	return nil
+}
+
+// runArgumentFlagsPreRun refuses the flags that don't apply to the given argument
+func runArgumentFlagsPreRun(cmd *cobra.Command, fArg string) error {
+	for _, argument := range runValidArgs {
+		if argument == fArg {
+			continue
+		}
 
+		for _, flag := range runArgumentFlags[argument] {
+			if cmd.Flags().Changed(flag) && !helper.ContainsString(runArgumentFlags[fArg], flag) {
+				return fmt.Errorf("--%s can only be used with run %s", flag, argument)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runListPreRun checks the filters of run list
+func runListPreRun(cmd *cobra.Command) error {
+	if _, err := aid.GetRunListOptions(cmd); err != nil {
+		return err
+	}
+
+	_, err := aid.GetRunListFilter(cmd, time.Now())
+	return err
+}
+
+// runReportPreRun checks the flags of run report
+func runReportPreRun(cmd *cobra.Command) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	if !helper.ContainsString(aid.RunReportFormats, format) {
+		return fmt.Errorf("invalid --format %q, valid values are: %s", format, strings.Join(aid.RunReportFormats, ", "))
+	}
+
+	return nil
+}
+
+// runApplyPreRun checks the flags of the apply preconditions
+func runApplyPreRun(cmd *cobra.Command) error {
+	expectedCommitSHA, err := cmd.Flags().GetString("expected-commit-sha")
+	if err != nil {
+		return fmt.Errorf("unable to get flag expected-commit-sha\n%w", err)
+	}
+
+	if cmd.Flags().Changed("expected-commit-sha") && len(expectedCommitSHA) < 7 {
+		return fmt.Errorf("invalid --expected-commit-sha %q, at least 7 characters are required", expectedCommitSHA)
+	}
+
+	return nil
+}
+
+// runCreatePreRun checks the options of the run to create
+func runCreatePreRun(cmd *cobra.Command) error {
+	options, err := aid.GetRunCreateOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := aid.CheckRunCreateOptions(options); err != nil {
+		return err
+	}
+
+	planOnly, err := cmd.Flags().GetBool("plan-only")
+	if err != nil {
+		return fmt.Errorf("unable to get flag plan-only\n%w", err)
+	}
+
+	if planOnly && cmd.Flags().Changed("discard-if-no-changes") {
+		return fmt.Errorf("--discard-if-no-changes can't be used with --plan-only, a plan-only run can't be discarded")
+	}
+
+	return nil
+}
+
+// runWaitPreRun checks the flags that only make sense when waiting for a created run
+func runWaitPreRun(cmd *cobra.Command) error {
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		return fmt.Errorf("unable to get flag wait\n%w", err)
+	}
+
+	for _, flag := range []string{"detailed-exitcode", "discard-if-no-changes", "poll-interval", "timeout"} {
+		if !wait && cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s requires --wait", flag)
+		}
+	}
+
+	interval, err := cmd.Flags().GetDuration("poll-interval")
+	if err != nil {
+		return fmt.Errorf("unable to get flag poll-interval\n%w", err)
+	}
+
+	if interval <= 0 {
+		return fmt.Errorf("invalid --poll-interval %s, it must be greater than zero", interval)
+	}
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return fmt.Errorf("unable to get flag timeout\n%w", err)
+	}
+
+	if timeout <= 0 {
+		return fmt.Errorf("invalid --timeout %s, it must be greater than zero", timeout)
 	}
 
 	return nil
@@ -85,7 +295,10 @@ func runPreRun(cmd *cobra.Command, args []string) error {
 func runRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	fArg := args[0]
 	switch fArg {
@@ -95,15 +308,41 @@ func runRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag workspace-id\n%w", err)
 		}
 
-		list, err := runList(client, workspaceID, tfe.RunListOptions{})
-		if err == nil {
-			aid.PrintRunList(list)
+		options, err := aid.GetRunListOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		filter, err := aid.GetRunListFilter(cmd, time.Now())
+		if err != nil {
+			return err
+		}
+
+		withCost, err := cmd.Flags().GetBool("with-cost")
+		if err != nil {
+			return fmt.Errorf("unable to get flag with-cost\n%w", err)
+		}
+
+		if withCost {
+			options.Include = []tfe.RunIncludeOpt{tfe.RunCostEstimate}
+		}
+
+		runs, err := runListFiltered(client, workspaceID, options, filter)
+		if err != nil {
+			return fmt.Errorf("no run was found\n%w", err)
+		}
+
+		if withCost {
+			fmt.Print(aid.RenderRunCostList(runs))
 		} else {
-			return fmt.Errorf("no run was found")
+			aid.PrintRunList(&tfe.RunList{Items: runs})
 		}
 
 	case "create":
-		options := aid.GetRunCreateOptions(cmd)
+		options, err := aid.GetRunCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
 
 		workspaceID, err := cmd.Flags().GetString("workspace-id")
 		if err != nil {
@@ -142,6 +381,15 @@ func runRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to create run\n%w", err)
 		}
 
+		wait, err := cmd.Flags().GetBool("wait")
+		if err != nil {
+			return fmt.Errorf("unable to get flag wait\n%w", err)
+		}
+
+		if wait {
+			return runCreateWait(cmd, client, run)
+		}
+
 	case "read":
 		id, err := cmd.Flags().GetString("id")
 		if err != nil {
@@ -160,7 +408,10 @@ func runRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetRunReadOptions(cmd)
+		options, err := aid.GetRunReadOptions(cmd)
+		if err != nil {
+			return err
+		}
 		run, err := runReadWithOptions(client, id, &options)
 		if err == nil {
 			fmt.Println(aid.ToJSON(run))
@@ -173,7 +424,22 @@ func runRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetRunApplyOptions(cmd)
+		options, err := aid.GetRunApplyOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		preconditions, err := aid.GetRunApplyPreconditions(cmd)
+		if err != nil {
+			return err
+		}
+
+		if !preconditions.IsEmpty() {
+			if err := runCheckApplyPreconditions(client, id, preconditions); err != nil {
+				return err
+			}
+		}
+
 		err = runApply(client, id, options)
 		if err != nil {
 			return fmt.Errorf("unable to apply run\n%w", err)
@@ -186,7 +452,10 @@ func runRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetRunCancelOptions(cmd)
+		options, err := aid.GetRunCancelOptions(cmd)
+		if err != nil {
+			return err
+		}
 		err = runCancel(client, id, options)
 		if err != nil {
 			return fmt.Errorf("unable to cancel run\n%w", err)
@@ -194,36 +463,17 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 		fmt.Println("run cancelled successfully")
 	case "cancel-all":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
-		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%w", err)
-		}
-
-		list, err := runList(client, workspaceID, tfe.RunListOptions{})
-		if err == nil {
-			for _, r := range list.Items {
-				if r.Actions.IsCancelable {
-
-					fmt.Printf("attempting to cancel run (%s)\n", r.ID)
-					options := aid.GetRunCancelOptions(cmd)
-					err := runCancel(client, r.ID, options)
-					if err != nil {
-						return fmt.Errorf("unable to cancel run (%s)", r.ID)
-					}
-
-					fmt.Printf("run (%s) cancelled successfully\n", r.ID)
-				}
-			}
-		} else {
-			return fmt.Errorf("no run was found")
-		}
+		return runBulkAction(cmd, client, "cancel")
 	case "force-cancel":
 		id, err := cmd.Flags().GetString("id")
 		if err != nil {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetRunForceCancelOptions(cmd)
+		options, err := aid.GetRunForceCancelOptions(cmd)
+		if err != nil {
+			return err
+		}
 		err = runForceCancel(client, id, options)
 		if err != nil {
 			return fmt.Errorf("unable to force ancel run\n%w", err)
@@ -231,65 +481,448 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 		fmt.Println("run cancelled successfully")
 	case "force-cancel-all":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
-		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%w", err)
-		}
-
-		list, err := runList(client, workspaceID, tfe.RunListOptions{})
-		if err == nil {
-			for _, r := range list.Items {
-				if r.Actions.IsForceCancelable {
-					fmt.Printf("attempting to force-cancel run (%s)\n", r.ID)
-					options := aid.GetRunForceCancelOptions(cmd)
-					err := runForceCancel(client, r.ID, options)
-					if err != nil {
-						return fmt.Errorf("unable to force cancel run (%s)", r.ID)
-					}
-					fmt.Printf("run (%s) force-cancelled successfully\n", r.ID)
-				}
-			}
-		} else {
-			return fmt.Errorf("no run was found")
-		}
-
+		return runBulkAction(cmd, client, "force-cancel")
 	case "discard":
 		id, err := cmd.Flags().GetString("id")
 		if err != nil {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetRunDiscardOptions(cmd)
+		options, err := aid.GetRunDiscardOptions(cmd)
+		if err != nil {
+			return err
+		}
 		err = runDiscard(client, id, options)
 		if err != nil {
 			return fmt.Errorf("unable to discard run\n%w", err)
 		}
 	case "discard-all":
-		workspaceID, err := cmd.Flags().GetString("workspace-id")
+		return runBulkAction(cmd, client, "discard")
+	case "report":
+		id, err := cmd.Flags().GetString("id")
 		if err != nil {
-			return fmt.Errorf("unable to get flag workspace-id\n%w", err)
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("unable to get flag format\n%w", err)
+		}
+
+		templatePath, err := cmd.Flags().GetString("template")
+		if err != nil {
+			return fmt.Errorf("unable to get flag template\n%w", err)
+		}
+
+		text, err := aid.GetRunReportTemplate(format, templatePath)
+		if err != nil {
+			return err
+		}
+
+		report, err := runReport(client, id)
+		if err != nil {
+			return err
 		}
 
-		list, err := runList(client, workspaceID, tfe.RunListOptions{})
+		out, err := aid.RenderRunReport(report, format, text)
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+	case "ps":
+		return runPs(cmd, client)
+	case "why":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		diagnosis, err := runWhy(client, id)
+		if err != nil {
+			return err
+		}
+		fmt.Print(aid.FormatRunDiagnosis(diagnosis))
+	}
+	return nil
+}
+
+// runCreateWait waits for the plan of a created run and prints a summary of its changes.
+// With --detailed-exitcode, a plan with changes is reported as an error with exit code 2.
+func runCreateWait(cmd *cobra.Command, client *tfe.Client, run *tfe.Run) error {
+	interval, err := cmd.Flags().GetDuration("poll-interval")
+	if err != nil {
+		return fmt.Errorf("unable to get flag poll-interval\n%w", err)
+	}
+
+	detailedExitCode, err := cmd.Flags().GetBool("detailed-exitcode")
+	if err != nil {
+		return fmt.Errorf("unable to get flag detailed-exitcode\n%w", err)
+	}
+
+	discard, err := cmd.Flags().GetBool("discard-if-no-changes")
+	if err != nil {
+		return fmt.Errorf("unable to get flag discard-if-no-changes\n%w", err)
+	}
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return fmt.Errorf("unable to get flag timeout\n%w", err)
+	}
+
+	run, err = runWaitForPlan(client, run.ID, interval, timeout)
+	if err != nil {
+		return fmt.Errorf("unable to wait for run %s\n%w", run.ID, err)
+	}
+
+	if aid.IsRunFailed(run.Status) {
+		return fmt.Errorf("run %s finished with status %s", run.ID, run.Status)
+	}
+
+	if run.Plan == nil {
+		return fmt.Errorf("run %s has no plan", run.ID)
+	}
+
+	plan, err := planRead(client, run.Plan.ID)
+	if err != nil {
+		return fmt.Errorf("unable to read plan %s\n%w", run.Plan.ID, err)
+	}
+
+	summary := aid.FormatPlanSummary(plan)
+	fmt.Println(summary)
+
+	if !aid.PlanHasChanges(plan) {
+		if discard {
+			return runDiscardUnchanged(cmd, client, run)
+		}
+
+		return nil
+	}
+
+	if detailedExitCode {
+		return &aid.Error{
+			Code:     aid.ExitCodeChanges,
+			Kind:     aid.ErrorKindChanges,
+			Message:  fmt.Sprintf("run %s has changes: %s", run.ID, summary),
+			Resource: "id=" + run.ID,
+		}
+	}
+
+	return nil
+}
+
+// withDetailedExitCode makes every error other than changes exit with 1 when --detailed-exitcode
+// is given, so the exit code is 0, 1 or 2 like terraform plan -detailed-exitcode
+func withDetailedExitCode(fn func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		err := fn(cmd, args)
 		if err == nil {
-			for _, r := range list.Items {
-				if r.Actions.IsDiscardable {
-					fmt.Printf("attempting to discard run (%s)\n", r.ID)
-					options := aid.GetRunDiscardOptions(cmd)
-					err := runDiscard(client, r.ID, options)
-					if err != nil {
-						return fmt.Errorf("unable to discard run (%s)", r.ID)
-					}
-					fmt.Printf("run (%s) discarded successfully\n", r.ID)
-				}
-			}
-		} else {
-			return fmt.Errorf("no run was found")
+			return nil
+		}
+
+		detailedExitCode, flagErr := cmd.Flags().GetBool("detailed-exitcode")
+		if flagErr != nil || !detailedExitCode {
+			return err
 		}
+
+		classified := *aid.ClassifyError(err)
+		if classified.Kind != aid.ErrorKindChanges {
+			classified.Code = aid.ExitCodeError
+		}
+
+		return &classified
 	}
+}
+
+// runWaitForPlan polls the run until its plan has finished, the run has failed or the timeout has passed
+func runWaitForPlan(client *tfe.Client, runID string, interval time.Duration, timeout time.Duration) (*tfe.Run, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		run, err := runRead(client, runID)
+		if err != nil {
+			return &tfe.Run{ID: runID}, err
+		}
+
+		if aid.IsRunPlanSettled(run.Status) || aid.IsRunFailed(run.Status) {
+			return run, nil
+		}
+
+		if time.Now().After(deadline) {
+			return run, fmt.Errorf("run %s is still %s after %s", run.ID, run.Status, timeout)
+		}
+
+		logrus.Debugf("run %s is %s, waiting %s", run.ID, run.Status, interval)
+		time.Sleep(interval)
+	}
+}
+
+// runDiscardUnchanged discards a run without changes so it doesn't stay pending on the workspace
+func runDiscardUnchanged(cmd *cobra.Command, client *tfe.Client, run *tfe.Run) error {
+	if run.Actions == nil || !run.Actions.IsDiscardable {
+		logrus.Debugf("run %s is %s and can't be discarded", run.ID, run.Status)
+		return nil
+	}
+
+	options, err := aid.GetRunDiscardOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	if options.Comment == nil {
+		comment := "discarded by tecli: the plan has no changes"
+		options.Comment = &comment
+	}
+
+	if err := runDiscard(client, run.ID, options); err != nil {
+		return fmt.Errorf("unable to discard run %s\n%w", run.ID, err)
+	}
+
+	fmt.Printf("run (%s) discarded successfully, the plan has no changes\n", run.ID)
 	return nil
 }
 
+// runCheckApplyPreconditions refuses to apply a run whose plan or configuration version doesn't meet the preconditions
+func runCheckApplyPreconditions(client *tfe.Client, runID string, preconditions aid.ApplyPreconditions) error {
+	readOptions := tfe.RunReadOptions{}
+	if preconditions.ExpectedCommitSHA != "" {
+		readOptions.Include = append(readOptions.Include, tfe.RunConfigVer, tfe.RunConfigVerIngress)
+	}
+	if preconditions.MaxDelta != nil {
+		readOptions.Include = append(readOptions.Include, tfe.RunCostEstimate)
+	}
+
+	run, err := runReadWithOptions(client, runID, &readOptions)
+	if err != nil {
+		return fmt.Errorf("run %s not found\n%w", runID, err)
+	}
+
+	if run.Plan == nil {
+		return fmt.Errorf("run %s has no plan", runID)
+	}
+
+	plan, err := planRead(client, run.Plan.ID)
+	if err != nil {
+		return fmt.Errorf("unable to read plan %s\n%w", run.Plan.ID, err)
+	}
+
+	if plan.Status != tfe.PlanFinished {
+		return fmt.Errorf("plan %s is %s, the preconditions can only be checked on a finished plan", plan.ID, plan.Status)
+	}
+
+	var planJSON *model.PlanJSON
+	if len(preconditions.DenyAddrs) > 0 {
+		data, err := planReadJSONOutput(client, plan.ID)
+		if err != nil {
+			return fmt.Errorf("unable to read the JSON output of plan %s\n%w", plan.ID, err)
+		}
+
+		planJSON, err = aid.ParsePlanJSON(data)
+		if err != nil {
+			return fmt.Errorf("unable to parse the JSON output of plan %s\n%w", plan.ID, err)
+		}
+	}
+
+	violations := aid.CheckPlanPreconditions(preconditions, plan, planJSON)
+	violations = append(violations, aid.CheckCostPreconditions(preconditions, run.CostEstimate)...)
+
+	if preconditions.ExpectedCommitSHA != "" {
+		cv := run.ConfigurationVersion
+		switch {
+		case cv == nil || cv.IngressAttributes == nil || cv.IngressAttributes.CommitSHA == "":
+			violations = append(violations, "the configuration version has no commit information")
+		case !strings.HasPrefix(cv.IngressAttributes.CommitSHA, preconditions.ExpectedCommitSHA):
+			violations = append(violations, fmt.Sprintf("the configuration version was built from commit %s, not %s", cv.IngressAttributes.CommitSHA, preconditions.ExpectedCommitSHA))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &aid.Error{
+			Code:     aid.ExitCodePrecondition,
+			Kind:     aid.ErrorKindPrecondition,
+			Message:  fmt.Sprintf("refusing to apply run %s (%s):\n  - %s", runID, aid.FormatPlanSummary(plan), strings.Join(violations, "\n  - ")),
+			Resource: "id=" + runID,
+		}
+	}
+
+	return nil
+}
+
+// runReport collects what a run report shows. Missing plan details and policy checks are left out of the report.
+func runReport(client *tfe.Client, runID string) (model.RunReport, error) {
+	options := tfe.RunReadOptions{Include: []tfe.RunIncludeOpt{tfe.RunWorkspace, tfe.RunPlan, tfe.RunCostEstimate}}
+	run, err := runReadWithOptions(client, runID, &options)
+	if err != nil {
+		return model.RunReport{}, fmt.Errorf("run %s not found\n%w", runID, err)
+	}
+
+	var summary model.PlanSummary
+	if run.Plan != nil && run.Plan.Status == tfe.PlanFinished {
+		summary, err = planReadSummary(client, run.Plan.ID)
+		if err != nil {
+			logrus.Warnf("the report has no resource changes\n%v", err)
+		}
+	}
+
+	policyChecks, err := client.PolicyChecks.List(context.Background(), runID, nil)
+	if err != nil {
+		logrus.Warnf("the report has no policy checks\nunable to list policy checks of run %s\n%v", runID, err)
+		policyChecks = &tfe.PolicyCheckList{}
+	}
+
+	return aid.NewRunReport(dao.GetOrganization(profile), run, run.Plan, summary, policyChecks.Items), nil
+}
+
+// runBulkAction cancels, force-cancels or discards the selected runs of the workspace, concurrently.
+// It goes on when an action fails, prints the result of every action and fails if any of them failed.
+func runBulkAction(cmd *cobra.Command, client *tfe.Client, action string) error {
+	workspaceID, err := cmd.Flags().GetString("workspace-id")
+	if err != nil {
+		return fmt.Errorf("unable to get flag workspace-id\n%w", err)
+	}
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return fmt.Errorf("unable to get flag concurrency\n%w", err)
+	}
+
+	listOptions, err := aid.GetRunListOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	filter, err := aid.GetRunListFilter(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// every matching run is selected unless --limit is given
+	if !cmd.Flags().Changed("limit") {
+		filter.Limit = 0
+	}
+
+	messageRegex, err := aid.GetRunMessageRegex(cmd)
+	if err != nil {
+		return err
+	}
+
+	// runs that ended can't be acted on, filtering them out on the API side avoids paging
+	// through the whole history of the workspace
+	if listOptions.Status == "" {
+		listOptions.Status = aid.GetRunActiveStatusFilter()
+	}
+
+	runs, err := runListFiltered(client, workspaceID, listOptions, filter)
+	if err != nil {
+		return fmt.Errorf("no run was found\n%w", err)
+	}
+
+	var selected []*tfe.Run
+	for _, r := range runs {
+		if aid.IsRunActionable(r, action) && (messageRegex == nil || messageRegex.MatchString(r.Message)) {
+			selected = append(selected, r)
+		}
+	}
+
+	if len(selected) == 0 {
+		fmt.Printf("no run to %s\n", action)
+		return nil
+	}
+
+	fmt.Printf("%d run(s) to %s\n", len(selected), action)
+
+	results := make([]aid.RunActionResult, len(selected))
+	aid.RunConcurrently(concurrency, len(selected), func(i int) {
+		r := selected[i]
+		logrus.Debugf("attempting to %s run (%s)", action, r.ID)
+		results[i] = aid.RunActionResult{RunID: r.ID, Status: string(r.Status), Action: action, Err: runAction(cmd, client, r.ID, action)}
+	})
+
+	fmt.Print(aid.RenderRunActionResults(results))
+
+	failed := 0
+	for _, result := range results {
+		addAuditResources("run=" + result.RunID)
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("unable to %s %d of %d run(s)", action, failed, len(results))
+	}
+
+	return nil
+}
+
+// runAction cancels, force-cancels or discards a run, with the comment given by --comment
+func runAction(cmd *cobra.Command, client *tfe.Client, runID string, action string) error {
+	switch action {
+	case "cancel":
+		options, err := aid.GetRunCancelOptions(cmd)
+		if err != nil {
+			return err
+		}
+		return runCancel(client, runID, options)
+	case "force-cancel":
+		options, err := aid.GetRunForceCancelOptions(cmd)
+		if err != nil {
+			return err
+		}
+		return runForceCancel(client, runID, options)
+	case "discard":
+		options, err := aid.GetRunDiscardOptions(cmd)
+		if err != nil {
+			return err
+		}
+		return runDiscard(client, runID, options)
+	}
+
+	return fmt.Errorf("unsupported run action %s", action)
+}
+
+// runListFiltered pages through the runs of the workspace, newest first, until --limit runs
+// created between --since and --until are found, then sorts them
+func runListFiltered(client *tfe.Client, workspaceID string, options tfe.RunListOptions, filter aid.RunListFilter) ([]*tfe.Run, error) {
+	var runs []*tfe.Run
+
+	options.PageNumber = 1
+	options.PageSize = 100
+	if filter.Limit > 0 && filter.Limit < options.PageSize {
+		options.PageSize = filter.Limit
+	}
+
+	for {
+		list, err := runList(client, workspaceID, options)
+		if err != nil {
+			return nil, err
+		}
+
+		done := false
+		for _, r := range list.Items {
+			if !filter.Since.IsZero() && r.CreatedAt.Before(filter.Since) {
+				done = true
+				break
+			}
+
+			if filter.InRunListWindow(r) {
+				runs = append(runs, r)
+			}
+
+			if filter.IsFull(len(runs)) {
+				done = true
+				break
+			}
+		}
+
+		if done || list.Pagination == nil || list.Pagination.NextPage == 0 {
+			break
+		}
+		options.PageNumber = list.Pagination.NextPage
+	}
+
+	aid.SortRuns(runs, filter.Sort)
+	return runs, nil
+}
+
 // List all the runs of the given workspace.
 func runList(client *tfe.Client, workspaceID string, options tfe.RunListOptions) (*tfe.RunList, error) {
 	return client.Runs.List(context.Background(), workspaceID, &options)
@@ -0,0 +1,118 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// runPs prints the active runs of every workspace of the organization, once or until interrupted with --watch
+func runPs(cmd *cobra.Command, client *tfe.Client) error {
+	watch, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return fmt.Errorf("unable to get flag watch\n%w", err)
+	}
+
+	interval, err := cmd.Flags().GetDuration("poll-interval")
+	if err != nil {
+		return fmt.Errorf("unable to get flag poll-interval\n%w", err)
+	}
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return fmt.Errorf("unable to get flag concurrency\n%w", err)
+	}
+
+	organization := dao.GetOrganization(profile)
+	if !watch {
+		rows, err := runPsRows(client, organization, concurrency)
+		if err != nil {
+			return err
+		}
+		fmt.Print(aid.RenderRunDashboard(rows, time.Now()))
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for {
+		rows, err := runPsRows(client, organization, concurrency)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		fmt.Print(clearScreen)
+		fmt.Printf("Every %s: %d active run(s) in %s at %s\n\n", interval, len(rows), organization, now.Format(time.RFC3339))
+		fmt.Print(aid.RenderRunDashboard(rows, now))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// runPsRows fetches the active runs of every workspace with a pool of concurrency workers,
+// paging through the runs the API returns for the active statuses.
+// A workspace whose runs can't be listed is skipped with a warning.
+func runPsRows(client *tfe.Client, organization string, concurrency int) ([]aid.RunDashboardRow, error) {
+	workspaces, err := workspaceListAll(client, organization, tfe.WorkspaceListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list workspaces\n%w", err)
+	}
+
+	var (
+		rows []aid.RunDashboardRow
+		mu   sync.Mutex
+	)
+
+	aid.RunConcurrently(concurrency, len(workspaces), func(i int) {
+		workspace := workspaces[i]
+		options := tfe.RunListOptions{Status: aid.GetRunActiveStatusFilter()}
+		runs, err := runListFiltered(client, workspace.ID, options, aid.RunListFilter{})
+		if err != nil {
+			logrus.Warnf("unable to list runs of workspace %s\n%v", workspace.Name, err)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, r := range runs {
+			if aid.IsRunActive(r.Status) {
+				rows = append(rows, aid.RunDashboardRow{Workspace: workspace.Name, Run: r})
+			}
+		}
+	})
+
+	return rows, nil
+}
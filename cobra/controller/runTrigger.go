@@ -0,0 +1,319 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var runTriggerValidArgs = []string{
+	"list",
+	"create",
+	"delete"}
+
+// RunTriggerCmd command to manage the run triggers between workspaces
+func RunTriggerCmd() *cobra.Command {
+	man, err := helper.GetManual("run-trigger", runTriggerValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    runTriggerValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      runTriggerPreRun,
+		RunE:         runTriggerRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetRunTriggerFlags(cmd)
+
+	return cmd
+}
+
+func runTriggerPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "run-trigger"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "run-trigger", fArg, "workspace"); err != nil {
+			return err
+		}
+
+		direction, err := cmd.Flags().GetString("direction")
+		if err != nil {
+			return fmt.Errorf("unable to get flag direction\n%w", err)
+		}
+
+		if !helper.ContainsString(aid.RunTriggerDirections, direction) {
+			return fmt.Errorf("invalid --direction %q, valid values are: %s", direction, strings.Join(aid.RunTriggerDirections, ", "))
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("unable to get flag format\n%w", err)
+		}
+
+		if !helper.ContainsString(aid.RunTriggerListFormats, format) {
+			return fmt.Errorf("invalid --format %q, valid values are: %s", format, strings.Join(aid.RunTriggerListFormats, ", "))
+		}
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "run-trigger", fArg, "workspace"); err != nil {
+			return err
+		}
+
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "run-trigger", fArg, "source"); err != nil {
+			return err
+		}
+
+	case "delete":
+		byID := cmd.Flags().Changed("id")
+		byNames := cmd.Flags().Changed("workspace") || cmd.Flags().Changed("source")
+		if byID == byNames {
+			return fmt.Errorf("--id or --workspace and --source are required by run-trigger delete, but not both")
+		}
+
+		if byNames && !(cmd.Flags().Changed("workspace") && cmd.Flags().Changed("source")) {
+			return fmt.Errorf("--workspace and --source must be defined together")
+		}
+
+	default:
+		return fmt.Errorf("unknown argument")
+	}
+
+	return nil
+}
+
+func runTriggerRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetOrganizationToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+	organization := dao.GetOrganization(profile)
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		return runTriggerList(cmd, client, organization)
+	case "create":
+		return runTriggerCreate(cmd, client, organization)
+	case "delete":
+		return runTriggerDelete(cmd, client, organization)
+	default:
+		return fmt.Errorf("unknown argument provided")
+	}
+}
+
+// runTriggerList prints the run triggers of the workspace given by --workspace in the direction given by --direction
+func runTriggerList(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	name, err := cmd.Flags().GetString("workspace")
+	if err != nil {
+		return fmt.Errorf("unable to get flag workspace\n%w", err)
+	}
+
+	direction, err := cmd.Flags().GetString("direction")
+	if err != nil {
+		return fmt.Errorf("unable to get flag direction\n%w", err)
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	workspace, err := workspaceRead(client, organization, name)
+	if err != nil {
+		return fmt.Errorf("workspace %s not found\n%w", name, err)
+	}
+
+	filters := []tfe.RunTriggerFilterOp{tfe.RunTriggerInbound, tfe.RunTriggerOutbound}
+	if direction != "all" {
+		filters = []tfe.RunTriggerFilterOp{tfe.RunTriggerFilterOp(direction)}
+	}
+
+	entries := []model.RunTriggerEntry{}
+	for _, filter := range filters {
+		triggers, err := runTriggerListAll(client, workspace.ID, filter)
+		if err != nil {
+			return fmt.Errorf("unable to list the %s run triggers of workspace %s\n%w", filter, name, err)
+		}
+
+		for _, rt := range triggers {
+			entries = append(entries, model.RunTriggerEntry{
+				ID:        rt.ID,
+				Direction: string(filter),
+				Source:    rt.SourceableName,
+				Workspace: rt.WorkspaceName,
+				CreatedAt: rt.CreatedAt.Format(time.RFC3339),
+			})
+		}
+	}
+
+	if format == "json" {
+		fmt.Println(aid.ToJSON(entries))
+	} else {
+		fmt.Print(aid.RenderRunTriggers(entries))
+	}
+
+	return nil
+}
+
+// runTriggerCreate makes the applies of --source queue runs in --workspace
+func runTriggerCreate(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	workspace, source, err := runTriggerWorkspaces(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	rt, err := client.RunTriggers.Create(context.Background(), workspace.ID, tfe.RunTriggerCreateOptions{Sourceable: source})
+	if err != nil {
+		return fmt.Errorf("unable to create the run trigger from %s to %s\n%w", source.Name, workspace.Name, err)
+	}
+
+	fmt.Printf("run trigger %s created, applies of %s queue runs in %s\n", rt.ID, source.Name, workspace.Name)
+	return nil
+}
+
+// runTriggerDelete deletes the run trigger given by --id, or the one from --source to --workspace
+func runTriggerDelete(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	id, err := cmd.Flags().GetString("id")
+	if err != nil {
+		return fmt.Errorf("unable to get flag id\n%w", err)
+	}
+
+	if id == "" {
+		workspace, source, err := runTriggerWorkspaces(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
+		rt, err := runTriggerFind(client, workspace, source)
+		if err != nil {
+			return err
+		}
+		id = rt.ID
+	}
+
+	if err := client.RunTriggers.Delete(context.Background(), id); err != nil {
+		return fmt.Errorf("unable to delete run trigger %s\n%w", id, err)
+	}
+
+	fmt.Printf("run trigger %s deleted\n", id)
+	return nil
+}
+
+// runTriggerWorkspaces returns the workspaces given by --workspace and --source
+func runTriggerWorkspaces(cmd *cobra.Command, client *tfe.Client, organization string) (*tfe.Workspace, *tfe.Workspace, error) {
+	var workspaces []*tfe.Workspace
+	for _, flag := range []string{"workspace", "source"} {
+		name, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to get flag %s\n%w", flag, err)
+		}
+
+		workspace, err := workspaceRead(client, organization, name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("workspace %s not found\n%w", name, err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	return workspaces[0], workspaces[1], nil
+}
+
+// runTriggerFind returns the run trigger from source to workspace
+func runTriggerFind(client *tfe.Client, workspace *tfe.Workspace, source *tfe.Workspace) (*tfe.RunTrigger, error) {
+	triggers, err := runTriggerListAll(client, workspace.ID, tfe.RunTriggerInbound)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the run triggers of workspace %s\n%w", workspace.Name, err)
+	}
+
+	for _, rt := range triggers {
+		if rt.SourceableName == source.Name || (rt.Sourceable != nil && rt.Sourceable.ID == source.ID) {
+			return rt, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no run trigger from %s to %s\n%w", source.Name, workspace.Name, tfe.ErrResourceNotFound)
+}
+
+// runTriggerGraph returns the run trigger graph of the organization. The inbound run triggers of every
+// workspace are enough to find all the edges, each run trigger being inbound to exactly one workspace.
+func runTriggerGraph(client *tfe.Client, organization string, concurrency int) (model.RunTriggerGraph, error) {
+	graph := aid.NewRunTriggerGraph()
+
+	workspaces, err := workspaceListAll(client, organization, tfe.WorkspaceListOptions{})
+	if err != nil {
+		return graph, fmt.Errorf("unable to list workspaces\n%w", err)
+	}
+
+	triggers := make([][]*tfe.RunTrigger, len(workspaces))
+	errs := make([]error, len(workspaces))
+	aid.RunConcurrently(concurrency, len(workspaces), func(i int) {
+		triggers[i], errs[i] = runTriggerListAll(client, workspaces[i].ID, tfe.RunTriggerInbound)
+	})
+
+	for i, workspace := range workspaces {
+		// a missing edge could hide a workspace that an apply triggers
+		if errs[i] != nil {
+			return graph, fmt.Errorf("unable to list the run triggers of workspace %s\n%w", workspace.Name, errs[i])
+		}
+
+		for _, rt := range triggers[i] {
+			aid.AddRunTrigger(&graph, rt.SourceableName, workspace.Name)
+		}
+	}
+
+	return graph, nil
+}
+
+func runTriggerListAll(client *tfe.Client, workspaceID string, filter tfe.RunTriggerFilterOp) ([]*tfe.RunTrigger, error) {
+	var triggers []*tfe.RunTrigger
+	options := tfe.RunTriggerListOptions{ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100}, RunTriggerType: filter}
+	for {
+		list, err := client.RunTriggers.List(context.Background(), workspaceID, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		triggers = append(triggers, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return triggers, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
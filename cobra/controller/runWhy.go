@@ -0,0 +1,101 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+)
+
+// runWhy gathers the lock, the current run, the queue and the agents of the run's workspace to explain why the run waits
+func runWhy(client *tfe.Client, runID string) (aid.RunDiagnosis, error) {
+	run, err := runRead(client, runID)
+	if err != nil {
+		return aid.RunDiagnosis{}, fmt.Errorf("run %s not found\n%w", runID, err)
+	}
+
+	if run.Workspace == nil {
+		return aid.RunDiagnosis{}, fmt.Errorf("run %s has no workspace", runID)
+	}
+
+	options := tfe.WorkspaceReadOptions{Include: []tfe.WSIncludeOpt{tfe.WSCurrentRun, tfe.WSLockedBy}}
+	workspace, err := client.Workspaces.ReadByIDWithOptions(context.Background(), run.Workspace.ID, &options)
+	if err != nil {
+		return aid.RunDiagnosis{}, fmt.Errorf("unable to read workspace %s\n%w", run.Workspace.ID, err)
+	}
+
+	in := aid.RunDiagnosisInput{Run: run, Workspace: workspace}
+	if run.Status != tfe.RunPending {
+		return aid.DiagnoseRun(in, time.Now()), nil
+	}
+
+	if workspace.LockedBy != nil && workspace.LockedBy.Run != nil && workspace.LockedBy.Run.ID != run.ID {
+		if in.LockingRun, err = runRead(client, workspace.LockedBy.Run.ID); err != nil {
+			logrus.Warnf("unable to read run %s holding the lock\n%v", workspace.LockedBy.Run.ID, err)
+		}
+	}
+
+	if workspace.CurrentRun != nil && workspace.CurrentRun.ID != run.ID {
+		if in.CurrentRun, err = runRead(client, workspace.CurrentRun.ID); err != nil {
+			logrus.Warnf("unable to read current run %s\n%v", workspace.CurrentRun.ID, err)
+		}
+	}
+
+	list, err := runList(client, workspace.ID, tfe.RunListOptions{ListOptions: tfe.ListOptions{PageSize: 100}})
+	if err != nil {
+		logrus.Warnf("unable to list the runs of workspace %s\n%v", workspace.Name, err)
+	} else {
+		for _, r := range list.Items {
+			if r.ID != run.ID && r.Status == tfe.RunPending && r.CreatedAt.Before(run.CreatedAt) {
+				in.QueuedAhead = append(in.QueuedAhead, r)
+			}
+		}
+	}
+
+	if workspace.ExecutionMode == "agent" && workspace.AgentPool != nil {
+		in.AgentPool, err = agentPoolAvailability(client, workspace.AgentPool.ID)
+		if err != nil {
+			logrus.Warnf("unable to list the agents of agent pool %s\n%v", workspace.AgentPool.ID, err)
+		}
+	}
+
+	return aid.DiagnoseRun(in, time.Now()), nil
+}
+
+// agentPoolAvailability counts the idle and busy agents of the pool
+func agentPoolAvailability(client *tfe.Client, agentPoolID string) (*aid.AgentPoolAvailability, error) {
+	list, err := client.Agents.List(context.Background(), agentPoolID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	availability := &aid.AgentPoolAvailability{ID: agentPoolID, Total: len(list.Items)}
+	for _, agent := range list.Items {
+		switch agent.Status {
+		case "idle":
+			availability.Idle++
+		case "busy":
+			availability.Busy++
+		}
+	}
+
+	return availability, nil
+}
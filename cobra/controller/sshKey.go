@@ -18,7 +18,6 @@ package controller
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
@@ -34,8 +33,7 @@ var sshKeyValidArgs = []string{"list", "create", "read", "update", "delete"}
 func SSHKeyCmd() *cobra.Command {
 	man, err := helper.GetManual("ssh-key", sshKeyValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -111,10 +109,12 @@ func sshKeyRun(cmd *cobra.Command, args []string) error {
 	// aid.LoadViper(config)
 
 	token := dao.GetTeamToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	var sshKey *tfe.SSHKey
-	var err error
 
 	fArg := args[0]
 	switch fArg {
@@ -124,12 +124,15 @@ func sshKeyRun(cmd *cobra.Command, args []string) error {
 		if err == nil {
 			fmt.Println(aid.ToJSON(list))
 		} else {
-			return fmt.Errorf("no ssh key was found")
+			return fmt.Errorf("no ssh key was found\n%w", err)
 		}
 
 	case "create":
 		organization := dao.GetOrganization(profile)
-		options := aid.GetSSHKeysCreateOptions(cmd)
+		options, err := aid.GetSSHKeysCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
 		sshKey, err = sshKeyCreate(client, organization, options)
 		if err != nil {
 			logrus.Errorln("unable to create ssh key")
@@ -158,7 +161,10 @@ func sshKeyRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetSSHKeysUpdateOptions(cmd)
+		options, err := aid.GetSSHKeysUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
 		sshKey, err = sshKeyUpdate(client, id, options)
 		if err == nil && sshKey.ID != "" {
 			fmt.Println(aid.ToJSON(sshKey))
@@ -0,0 +1,508 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var stateValidArgs = []string{"list", "read", "current", "download", "outputs", "push", "migrate"}
+
+// StateCmd command to read and upload the state versions of workspaces
+func StateCmd() *cobra.Command {
+	man, err := helper.GetManual("state", stateValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    stateValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      statePreRun,
+		RunE:         stateRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetStateFlags(cmd)
+
+	return cmd
+}
+
+// stateArgumentFlags are the flags that only apply to some arguments of state
+var stateArgumentFlags = map[string][]string{
+	"list":     {"format", "limit"},
+	"download": {"serial", "out", "decompress", "pretty"},
+	"outputs":  {"raw"},
+	"push":     {"file", "force"},
+	"migrate":  {"file", "force", "progress", "dry-run", "verify-timeout"},
+}
+
+func statePreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "state"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list", "current":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "state", fArg, "workspace"); err != nil {
+			return err
+		}
+	case "push":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "state", fArg, "workspace"); err != nil {
+			return err
+		}
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "state", fArg, "file"); err != nil {
+			return err
+		}
+	case "migrate":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "state", fArg, "file"); err != nil {
+			return err
+		}
+	case "read":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "state", fArg, "id"); err != nil {
+			return err
+		}
+	case "download", "outputs":
+		if cmd.Flags().Changed("id") == cmd.Flags().Changed("workspace") {
+			return fmt.Errorf("--id or --workspace is required by state %s, but not both", fArg)
+		}
+	}
+
+	for argument, flags := range stateArgumentFlags {
+		for _, flag := range flags {
+			if argument != fArg && cmd.Flags().Changed(flag) && !helper.ContainsString(stateArgumentFlags[fArg], flag) {
+				return fmt.Errorf("--%s can only be used with state %s", flag, argument)
+			}
+		}
+	}
+
+	if cmd.Flags().Changed("serial") && !cmd.Flags().Changed("workspace") {
+		return fmt.Errorf("--serial requires --workspace")
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	if !helper.ContainsString(aid.StateListFormats, format) {
+		return fmt.Errorf("invalid --format %q, valid values are: %s", format, strings.Join(aid.StateListFormats, ", "))
+	}
+
+	return nil
+}
+
+func stateRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetTeamToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+	organization := dao.GetOrganization(profile)
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		workspace, err := cmd.Flags().GetString("workspace")
+		if err != nil {
+			return fmt.Errorf("unable to get flag workspace\n%w", err)
+		}
+
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			return fmt.Errorf("unable to get flag limit\n%w", err)
+		}
+
+		versions, err := stateVersionList(client, organization, workspace, limit)
+		if err != nil {
+			return fmt.Errorf("unable to list the state versions of workspace %s\n%w", workspace, err)
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("unable to get flag format\n%w", err)
+		}
+
+		if format == "json" {
+			fmt.Println(aid.ToJSON(versions))
+		} else {
+			fmt.Print(aid.RenderStateVersions(versions))
+		}
+	case "read":
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		sv, err := stateVersionRead(client, id)
+		if err != nil {
+			return fmt.Errorf("state version %s not found\n%w", id, err)
+		}
+		fmt.Println(aid.ToJSON(sv))
+	case "current":
+		sv, err := stateVersionResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+		fmt.Println(aid.ToJSON(sv))
+	case "download":
+		return stateDownload(cmd, client, organization)
+	case "outputs":
+		return stateOutputs(cmd, client, organization)
+	case "push":
+		workspace, err := cmd.Flags().GetString("workspace")
+		if err != nil {
+			return fmt.Errorf("unable to get flag workspace\n%w", err)
+		}
+
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return fmt.Errorf("unable to get flag file\n%w", err)
+		}
+
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			return fmt.Errorf("unable to get flag force\n%w", err)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("unable to read state file %s\n%w", file, err)
+		}
+
+		ws, err := workspaceRead(client, organization, workspace)
+		if err != nil {
+			return fmt.Errorf("unable to find workspace %s\n%w", workspace, err)
+		}
+
+		sv, err := statePush(client, ws, data, force)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(aid.ToJSON(sv))
+	case "migrate":
+		return stateMigrate(cmd, client, organization)
+	}
+
+	return nil
+}
+
+// stateVersionResolve reads the state version given by --id, or by --serial or the current one of --workspace
+func stateVersionResolve(cmd *cobra.Command, client *tfe.Client, organization string) (*tfe.StateVersion, error) {
+	if cmd.Flags().Changed("id") {
+		id, err := cmd.Flags().GetString("id")
+		if err != nil {
+			return nil, fmt.Errorf("unable to get flag id\n%w", err)
+		}
+
+		sv, err := stateVersionRead(client, id)
+		if err != nil {
+			return nil, fmt.Errorf("state version %s not found\n%w", id, err)
+		}
+
+		return sv, nil
+	}
+
+	name, err := cmd.Flags().GetString("workspace")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag workspace\n%w", err)
+	}
+
+	if cmd.Flags().Changed("serial") {
+		serial, err := cmd.Flags().GetInt64("serial")
+		if err != nil {
+			return nil, fmt.Errorf("unable to get flag serial\n%w", err)
+		}
+
+		return stateVersionFindBySerial(client, organization, name, serial)
+	}
+
+	workspace, err := workspaceRead(client, organization, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find workspace %s\n%w", name, err)
+	}
+
+	sv, err := stateVersionReadCurrent(client, workspace.ID)
+	if errors.Is(err, tfe.ErrResourceNotFound) {
+		return nil, fmt.Errorf("workspace %s has no state\n%w", name, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the current state version of workspace %s\n%w", name, err)
+	}
+
+	return sv, nil
+}
+
+// stateDownload writes the state of a state version to --out or stdout
+func stateDownload(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	sv, err := stateVersionResolve(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	data, err := stateVersionDownload(client, sv)
+	if err != nil {
+		return err
+	}
+
+	decompress, err := cmd.Flags().GetBool("decompress")
+	if err != nil {
+		return fmt.Errorf("unable to get flag decompress\n%w", err)
+	}
+
+	pretty, err := cmd.Flags().GetBool("pretty")
+	if err != nil {
+		return fmt.Errorf("unable to get flag pretty\n%w", err)
+	}
+
+	data, err = aid.DecodeStateDownload(data, decompress, pretty)
+	if err != nil {
+		return err
+	}
+
+	out, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return fmt.Errorf("unable to get flag out\n%w", err)
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	// a state holds secrets in clear text
+	if err := os.WriteFile(out, data, 0600); err != nil {
+		return fmt.Errorf("unable to write state to %s\n%w", out, err)
+	}
+
+	logrus.Infof("state version %s (serial %d) written to %s", sv.ID, sv.Serial, out)
+
+	return nil
+}
+
+// stateOutputs prints the non-sensitive outputs of a state version as JSON, or a single output with --raw
+func stateOutputs(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	sv, err := stateVersionResolve(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	outputs, err := stateVersionListOutputs(client, sv.ID)
+	if err != nil {
+		return fmt.Errorf("unable to list the outputs of state version %s\n%w", sv.ID, err)
+	}
+
+	raw, err := cmd.Flags().GetString("raw")
+	if err != nil {
+		return fmt.Errorf("unable to get flag raw\n%w", err)
+	}
+
+	if raw != "" {
+		for _, o := range outputs {
+			if o.Name == raw {
+				value, err := aid.FormatRawOutput(o)
+				if err != nil {
+					return err
+				}
+
+				fmt.Print(value)
+				return nil
+			}
+		}
+
+		return fmt.Errorf("output %s not found in state version %s", raw, sv.ID)
+	}
+
+	values, sensitive := aid.GetStateOutputValues(outputs)
+	if len(sensitive) > 0 {
+		logrus.Infof("sensitive outputs left out: %s", strings.Join(sensitive, ", "))
+	}
+
+	fmt.Println(aid.ToJSON(values))
+
+	return nil
+}
+
+// statePush uploads a state as the new state version of a workspace. The workspace stays locked
+// from the check of the current state until the upload is done. When the state is already the
+// current one, nothing is uploaded and the current state version is returned.
+func statePush(client *tfe.Client, workspace *tfe.Workspace, data []byte, force bool) (sv *tfe.StateVersion, err error) {
+	local, err := aid.ParseStateFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := workspaceLock(client, workspace.ID); err != nil {
+		return nil, fmt.Errorf("unable to lock workspace %s\n%w", workspace.Name, err)
+	}
+
+	defer func() {
+		if _, unlockErr := workspaceUnlock(client, workspace.ID); unlockErr != nil {
+			unlockErr = fmt.Errorf("unable to unlock workspace %s, unlock it with tecli workspace unlock --id %s\n%w", workspace.Name, workspace.ID, unlockErr)
+			if err == nil {
+				err = unlockErr
+			} else {
+				logrus.Error(unlockErr)
+			}
+		}
+	}()
+
+	current, err := stateVersionReadCurrent(client, workspace.ID)
+	if err != nil && !errors.Is(err, tfe.ErrResourceNotFound) {
+		return nil, fmt.Errorf("unable to read the current state version of workspace %s\n%w", workspace.Name, err)
+	}
+
+	var currentState *model.StateFile
+	if current != nil && err == nil {
+		currentData, err := stateVersionDownload(client, current)
+		if err != nil {
+			return nil, err
+		}
+
+		if aid.GetStateMD5(currentData) == aid.GetStateMD5(data) {
+			logrus.Infof("the state file is already the current state of workspace %s", workspace.Name)
+			return current, nil
+		}
+
+		currentState, err = aid.ParseStateFile(currentData)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the current state of workspace %s\n%w", workspace.Name, err)
+		}
+	}
+
+	if violations := aid.CheckStatePush(local, currentState, force); len(violations) > 0 {
+		return nil, &aid.Error{
+			Code:     aid.ExitCodePrecondition,
+			Kind:     aid.ErrorKindPrecondition,
+			Message:  fmt.Sprintf("refusing to push the state to workspace %s, use --force to push it anyway:\n  - %s", workspace.Name, strings.Join(violations, "\n  - ")),
+			Resource: "workspace=" + workspace.Name,
+		}
+	}
+
+	options := aid.GetStateVersionCreateOptions(local, data, force)
+	sv, err = client.StateVersions.Create(context.Background(), workspace.ID, options)
+	if err != nil {
+		return nil, fmt.Errorf("unable to upload the state to workspace %s\n%w", workspace.Name, err)
+	}
+
+	return sv, nil
+}
+
+// stateVersionList pages through the state versions of a workspace, newest first, until limit state versions
+func stateVersionList(client *tfe.Client, organization string, workspace string, limit int) ([]*tfe.StateVersion, error) {
+	options := tfe.StateVersionListOptions{
+		ListOptions:  tfe.ListOptions{PageNumber: 1, PageSize: 100},
+		Organization: organization,
+		Workspace:    workspace,
+	}
+
+	var versions []*tfe.StateVersion
+	for {
+		list, err := client.StateVersions.List(context.Background(), &options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sv := range list.Items {
+			if limit > 0 && len(versions) == limit {
+				return versions, nil
+			}
+			versions = append(versions, sv)
+		}
+
+		if list.Pagination == nil || list.NextPage == 0 {
+			return versions, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
+
+// stateVersionFindBySerial pages through the state versions of a workspace until the one with the given serial
+func stateVersionFindBySerial(client *tfe.Client, organization string, workspace string, serial int64) (*tfe.StateVersion, error) {
+	versions, err := stateVersionList(client, organization, workspace, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the state versions of workspace %s\n%w", workspace, err)
+	}
+
+	for _, sv := range versions {
+		if sv.Serial == serial {
+			return sv, nil
+		}
+	}
+
+	return nil, fmt.Errorf("workspace %s has no state version with serial %d\n%w", workspace, serial, tfe.ErrResourceNotFound)
+}
+
+// Read a state version by its ID.
+func stateVersionRead(client *tfe.Client, stateVersionID string) (*tfe.StateVersion, error) {
+	return client.StateVersions.Read(context.Background(), stateVersionID)
+}
+
+// ReadCurrent reads the latest available state from the given workspace.
+func stateVersionReadCurrent(client *tfe.Client, workspaceID string) (*tfe.StateVersion, error) {
+	return client.StateVersions.ReadCurrent(context.Background(), workspaceID)
+}
+
+// stateVersionDownload downloads the state of a state version
+func stateVersionDownload(client *tfe.Client, sv *tfe.StateVersion) ([]byte, error) {
+	if sv.DownloadURL == "" {
+		return nil, fmt.Errorf("state version %s has no download URL, the token may lack permission to read the state", sv.ID)
+	}
+
+	data, err := client.StateVersions.Download(context.Background(), sv.DownloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download state version %s\n%w", sv.ID, err)
+	}
+
+	return data, nil
+}
+
+// stateVersionListOutputs pages through the outputs of a state version
+func stateVersionListOutputs(client *tfe.Client, stateVersionID string) ([]*tfe.StateVersionOutput, error) {
+	var outputs []*tfe.StateVersionOutput
+	options := tfe.StateVersionOutputsListOptions{ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100}}
+	for {
+		list, err := client.StateVersions.ListOutputs(context.Background(), stateVersionID, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return outputs, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
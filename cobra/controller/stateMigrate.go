@@ -0,0 +1,216 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// stateVerifyInterval is how often a pushed state version is read until its resources are processed
+const stateVerifyInterval = 2 * time.Second
+
+// stateMigrate pushes the state files of a manifest in order, creating the missing workspaces. The outcome
+// of each push is recorded in the progress file, and the state files already pushed are skipped, so running
+// the same command again after a failure resumes the migration where it stopped.
+func stateMigrate(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	manifestPath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("unable to get flag file\n%w", err)
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return fmt.Errorf("unable to get flag force\n%w", err)
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("unable to get flag dry-run\n%w", err)
+	}
+
+	verifyTimeout, err := cmd.Flags().GetDuration("verify-timeout")
+	if err != nil {
+		return fmt.Errorf("unable to get flag verify-timeout\n%w", err)
+	}
+
+	progressPath, err := cmd.Flags().GetString("progress")
+	if err != nil {
+		return fmt.Errorf("unable to get flag progress\n%w", err)
+	}
+	if progressPath == "" {
+		progressPath = manifestPath + ".progress.json"
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("unable to read manifest %s\n%w", manifestPath, err)
+	}
+
+	manifest, err := aid.ParseStateMigrationManifest(data)
+	if err != nil {
+		return err
+	}
+
+	items, err := aid.ExpandStateMigration(manifest, filepath.Dir(manifestPath))
+	if err != nil {
+		return err
+	}
+
+	progress, err := aid.ReadStateMigrationProgress(progressPath)
+	if err != nil {
+		return err
+	}
+
+	// the whole manifest is refused up front rather than stopping halfway through the migration
+	if !dryRun {
+		var workspaces []string
+		for _, item := range items {
+			if !helper.ContainsString(workspaces, item.Workspace) {
+				workspaces = append(workspaces, item.Workspace)
+			}
+		}
+
+		if err := checkProtectedWorkspaces(workspaces); err != nil {
+			return err
+		}
+	}
+
+	// workspaces are read and created with the organization token, like the workspace command does
+	workspaceClient, err := aid.GetTFEClient(dao.GetOrganizationToken(profile))
+	if err != nil {
+		return err
+	}
+
+	for i, item := range items {
+		prefix := fmt.Sprintf("[%d/%d] %s -> %s:", i+1, len(items), item.File, item.Workspace)
+
+		state, err := os.ReadFile(item.File)
+		if err != nil {
+			return fmt.Errorf("%s unable to read state file\n%w", prefix, err)
+		}
+
+		checksum := aid.GetStateMD5(state)
+		if aid.IsStateMigrationDone(progress, item, checksum) {
+			fmt.Printf("%s already migrated\n", prefix)
+			continue
+		}
+
+		if dryRun {
+			_, err := workspaceRead(workspaceClient, organization, item.Workspace)
+			switch {
+			case errors.Is(err, tfe.ErrResourceNotFound):
+				fmt.Printf("%s would create the workspace and push the state\n", prefix)
+			case err != nil:
+				return fmt.Errorf("%s unable to read workspace\n%w", prefix, err)
+			default:
+				fmt.Printf("%s would push the state\n", prefix)
+			}
+			continue
+		}
+
+		entry := model.StateMigrationProgressEntry{File: item.File, Workspace: item.Workspace, MD5: checksum, Status: aid.StateMigrationDone}
+		sv, resources, err := stateMigrateItem(workspaceClient, client, organization, item, state, force, verifyTimeout)
+		if err != nil {
+			entry.Status = aid.StateMigrationFailed
+			entry.Error = err.Error()
+		} else {
+			entry.StateVersionID, entry.Serial, entry.Resources = sv.ID, sv.Serial, resources
+		}
+		entry.UpdatedAt = time.Now().UTC()
+
+		aid.SetStateMigrationProgress(progress, entry)
+		if writeErr := aid.WriteStateMigrationProgress(progressPath, progress); writeErr != nil {
+			return writeErr
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s migration stopped, run the same command to resume it once fixed\n%w", prefix, err)
+		}
+
+		fmt.Printf("%s pushed serial %d as %s, %d resource(s)\n", prefix, sv.Serial, sv.ID, resources)
+	}
+
+	return nil
+}
+
+// stateMigrateItem creates the workspace if needed, pushes the state and checks that the
+// workspace counts as many resources as the state file
+func stateMigrateItem(workspaceClient *tfe.Client, client *tfe.Client, organization string, item model.StateMigrationItem, data []byte, force bool, verifyTimeout time.Duration) (*tfe.StateVersion, int, error) {
+	local, err := aid.ParseStateFile(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	workspace, err := workspaceRead(workspaceClient, organization, item.Workspace)
+	if errors.Is(err, tfe.ErrResourceNotFound) {
+		workspace, err = workspaceCreate(workspaceClient, organization, tfe.WorkspaceCreateOptions{Name: &item.Workspace})
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to create workspace\n%w", err)
+		}
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to read workspace\n%w", err)
+	}
+
+	sv, err := statePush(client, workspace, data, force)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sv, err = stateVersionWaitProcessed(client, sv.ID, verifyTimeout)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	expected, actual := aid.CountStateInstances(local), aid.CountStateVersionResources(sv)
+	if expected != actual {
+		return nil, 0, fmt.Errorf("state version %s has %d resource(s), the state file has %d", sv.ID, actual, expected)
+	}
+
+	return sv, actual, nil
+}
+
+// stateVersionWaitProcessed reads a state version until its resources are processed
+func stateVersionWaitProcessed(client *tfe.Client, stateVersionID string, timeout time.Duration) (*tfe.StateVersion, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		sv, err := stateVersionRead(client, stateVersionID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read state version %s\n%w", stateVersionID, err)
+		}
+
+		if sv.ResourcesProcessed {
+			return sv, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the resources of state version %s weren't processed after %s", stateVersionID, timeout)
+		}
+
+		time.Sleep(stateVerifyInterval)
+	}
+}
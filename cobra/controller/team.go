@@ -0,0 +1,282 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var teamValidArgs = []string{
+	"list",
+	"create",
+	"read",
+	"update",
+	"delete",
+	"add-member",
+	"remove-member"}
+
+// TeamCmd command to manage the teams of an organization and their members
+func TeamCmd() *cobra.Command {
+	man, err := helper.GetManual("team", teamValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    teamValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      teamPreRun,
+		RunE:         teamRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetTeamFlags(cmd)
+
+	return cmd
+}
+
+func teamPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "team"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		// skipping...
+		return nil
+
+	case "create":
+		if err := helper.ValidateCmdArgAndFlag(cmd, args, "team", fArg, "name"); err != nil {
+			return err
+		}
+
+	case "read",
+		"update",
+		"delete",
+		"add-member",
+		"remove-member":
+		if cmd.Flags().Changed("id") == cmd.Flags().Changed("name") {
+			return fmt.Errorf("--id or --name is required by team %s, but not both", fArg)
+		}
+
+		if fArg == "add-member" || fArg == "remove-member" {
+			if _, err := aid.GetTeamUsernames(cmd); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unknown argument")
+	}
+
+	return nil
+}
+
+func teamRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetOrganizationToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+	organization := dao.GetOrganization(profile)
+
+	fArg := args[0]
+	switch fArg {
+	case "list":
+		search, err := cmd.Flags().GetString("search")
+		if err != nil {
+			return fmt.Errorf("unable to get flag search\n%w", err)
+		}
+
+		teams, err := teamListAll(client, organization, tfe.TeamListOptions{Query: search})
+		if err != nil {
+			return fmt.Errorf("unable to list teams\n%w", err)
+		}
+		fmt.Println(aid.ToJSON(teams))
+
+	case "create":
+		options, err := aid.GetTeamCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		team, err := teamCreate(client, organization, options)
+		if err != nil {
+			return fmt.Errorf("unable to create team\n%w", err)
+		}
+		fmt.Println(aid.ToJSON(team))
+
+	case "read":
+		team, err := teamResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+		fmt.Println(aid.ToJSON(team))
+
+	case "update":
+		options, err := aid.GetTeamUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		team, err := teamResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
+		team, err = teamUpdate(client, team.ID, options)
+		if err != nil {
+			return fmt.Errorf("unable to update team\n%w", err)
+		}
+		fmt.Println(aid.ToJSON(team))
+
+	case "delete":
+		team, err := teamResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
+		if err := teamDelete(client, team.ID); err != nil {
+			return fmt.Errorf("unable to delete team %s\n%w", team.Name, err)
+		}
+		cmd.Printf("team %s deleted successfully\n", team.Name)
+
+	case "add-member", "remove-member":
+		usernames, err := aid.GetTeamUsernames(cmd)
+		if err != nil {
+			return err
+		}
+
+		team, err := teamResolve(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
+		if fArg == "add-member" {
+			if err := teamMemberAdd(client, team.ID, usernames); err != nil {
+				return fmt.Errorf("unable to add %s to team %s\n%w", strings.Join(usernames, ", "), team.Name, err)
+			}
+			cmd.Printf("%s added to team %s\n", strings.Join(usernames, ", "), team.Name)
+		} else {
+			if err := teamMemberRemove(client, team.ID, usernames); err != nil {
+				return fmt.Errorf("unable to remove %s from team %s\n%w", strings.Join(usernames, ", "), team.Name, err)
+			}
+			cmd.Printf("%s removed from team %s\n", strings.Join(usernames, ", "), team.Name)
+		}
+
+	default:
+		return fmt.Errorf("unknown argument provided")
+	}
+
+	return nil
+}
+
+// teamResolve reads the team given by --id, or finds the team given by --name
+func teamResolve(cmd *cobra.Command, client *tfe.Client, organization string) (*tfe.Team, error) {
+	id, err := cmd.Flags().GetString("id")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag id\n%w", err)
+	}
+
+	if id != "" {
+		team, err := teamRead(client, id)
+		if err != nil {
+			return nil, fmt.Errorf("team %s not found\n%w", id, err)
+		}
+		return team, nil
+	}
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag name\n%w", err)
+	}
+
+	return teamFindByName(client, organization, name)
+}
+
+// teamListAll pages through the teams of an organization
+func teamListAll(client *tfe.Client, organization string, options tfe.TeamListOptions) ([]*tfe.Team, error) {
+	var teams []*tfe.Team
+	options.PageNumber = 1
+	options.PageSize = 100
+	for {
+		list, err := client.Teams.List(context.Background(), organization, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return teams, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
+
+// teamFindByName looks up a team by exact name, the names filter matching names exactly
+func teamFindByName(client *tfe.Client, organization string, name string) (*tfe.Team, error) {
+	teams, err := teamListAll(client, organization, tfe.TeamListOptions{Names: []string{name}})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list teams\n%w", err)
+	}
+
+	for _, team := range teams {
+		if team.Name == name {
+			return team, nil
+		}
+	}
+
+	return nil, fmt.Errorf("team %s not found\n%w", name, tfe.ErrResourceNotFound)
+}
+
+func teamCreate(client *tfe.Client, organization string, options tfe.TeamCreateOptions) (*tfe.Team, error) {
+	return client.Teams.Create(context.Background(), organization, options)
+}
+
+func teamRead(client *tfe.Client, teamID string) (*tfe.Team, error) {
+	return client.Teams.Read(context.Background(), teamID)
+}
+
+func teamUpdate(client *tfe.Client, teamID string, options tfe.TeamUpdateOptions) (*tfe.Team, error) {
+	return client.Teams.Update(context.Background(), teamID, options)
+}
+
+func teamDelete(client *tfe.Client, teamID string) error {
+	return client.Teams.Delete(context.Background(), teamID)
+}
+
+func teamMemberAdd(client *tfe.Client, teamID string, usernames []string) error {
+	return client.TeamMembers.Add(context.Background(), teamID, tfe.TeamMemberAddOptions{Usernames: usernames})
+}
+
+func teamMemberRemove(client *tfe.Client, teamID string, usernames []string) error {
+	return client.TeamMembers.Remove(context.Background(), teamID, tfe.TeamMemberRemoveOptions{Usernames: usernames})
+}
@@ -0,0 +1,424 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/dao"
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/awslabs/tecli/helper"
+	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var teamAccessValidArgs = []string{
+	"list",
+	"add",
+	"update",
+	"remove",
+	"matrix"}
+
+// TeamAccessCmd command to manage the access of teams to workspaces
+func TeamAccessCmd() *cobra.Command {
+	man, err := helper.GetManual("team-access", teamAccessValidArgs)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := &cobra.Command{
+		Use:          man.Use,
+		Short:        man.Short,
+		Long:         man.Long,
+		Example:      man.Example,
+		ValidArgs:    teamAccessValidArgs,
+		Args:         cobra.OnlyValidArgs,
+		PreRunE:      teamAccessPreRun,
+		RunE:         teamAccessRun,
+		SilenceUsage: true,
+	}
+
+	aid.SetTeamAccessFlags(cmd)
+
+	return cmd
+}
+
+func teamAccessPreRun(cmd *cobra.Command, args []string) error {
+	if err := helper.ValidateCmdArgs(cmd, args, "team-access"); err != nil {
+		return err
+	}
+
+	fArg := args[0]
+	switch fArg {
+	case "list", "matrix":
+		if cmd.Flags().Changed("team") && cmd.Flags().Changed("team-id") {
+			return fmt.Errorf("--team and --team-id can't be used together")
+		}
+
+		if fArg == "matrix" && (cmd.Flags().Changed("team") || cmd.Flags().Changed("team-id")) {
+			return fmt.Errorf("--team and --team-id can't be used with team-access matrix, the matrix reports every team")
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("unable to get flag format\n%w", err)
+		}
+
+		formats := aid.TeamAccessListFormats
+		if fArg == "matrix" {
+			formats = aid.TeamAccessMatrixFormats
+		}
+
+		if !helper.ContainsString(formats, format) {
+			return fmt.Errorf("invalid --format %q, valid values are: %s", format, strings.Join(formats, ", "))
+		}
+
+		return runConcurrencyPreRun(cmd)
+
+	case "add", "update", "remove":
+		if cmd.Flags().Changed("team") == cmd.Flags().Changed("team-id") {
+			return fmt.Errorf("--team or --team-id is required by team-access %s, but not both", fArg)
+		}
+
+		if !cmd.Flags().Changed("workspace") {
+			return fmt.Errorf("--workspace must be defined")
+		}
+
+		var err error
+		switch fArg {
+		case "add":
+			_, err = aid.GetTeamAccessAddOptions(cmd)
+		case "update":
+			_, err = aid.GetTeamAccessUpdateOptions(cmd)
+		}
+		return err
+
+	default:
+		return fmt.Errorf("unknown argument")
+	}
+}
+
+func teamAccessRun(cmd *cobra.Command, args []string) error {
+
+	token := dao.GetOrganizationToken(profile)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
+	organization := dao.GetOrganization(profile)
+
+	fArg := args[0]
+	switch fArg {
+	case "list", "matrix":
+		return teamAccessReport(cmd, client, organization, fArg)
+	case "add", "update", "remove":
+		return teamAccessChange(cmd, client, organization, fArg)
+	default:
+		return fmt.Errorf("unknown argument provided")
+	}
+}
+
+// teamAccessChange adds, updates or removes the access of a team to every workspace given by --workspace.
+// A failure on a workspace doesn't stop the others.
+func teamAccessChange(cmd *cobra.Command, client *tfe.Client, organization string, action string) error {
+	team, err := teamAccessTeam(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := teamAccessWorkspaces(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, workspace := range workspaces {
+		var message string
+		switch action {
+		case "add":
+			message, err = teamAccessAdd(cmd, client, team, workspace)
+		case "update":
+			message, err = teamAccessUpdate(cmd, client, team, workspace)
+		case "remove":
+			message, err = teamAccessRemove(client, team, workspace)
+		}
+
+		if err != nil {
+			failed++
+			fmt.Printf("%s: unable to %s the access of team %s\n%v\n", workspace.Name, action, team.Name, err)
+			continue
+		}
+		fmt.Printf("%s: %s\n", workspace.Name, message)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("unable to %s the access of team %s to %d of %d workspace(s)", action, team.Name, failed, len(workspaces))
+	}
+
+	return nil
+}
+
+func teamAccessAdd(cmd *cobra.Command, client *tfe.Client, team *tfe.Team, workspace *tfe.Workspace) (string, error) {
+	options, err := aid.GetTeamAccessAddOptions(cmd)
+	if err != nil {
+		return "", err
+	}
+	options.Team = team
+	options.Workspace = workspace
+
+	ta, err := client.TeamAccess.Add(context.Background(), options)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s access given to team %s (%s)", ta.Access, team.Name, ta.ID), nil
+}
+
+func teamAccessUpdate(cmd *cobra.Command, client *tfe.Client, team *tfe.Team, workspace *tfe.Workspace) (string, error) {
+	options, err := aid.GetTeamAccessUpdateOptions(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	current, err := teamAccessFind(client, team, workspace)
+	if err != nil {
+		return "", err
+	}
+
+	ta, err := client.TeamAccess.Update(context.Background(), current.ID, options)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("access of team %s changed from %s to %s", team.Name, current.Access, ta.Access), nil
+}
+
+func teamAccessRemove(client *tfe.Client, team *tfe.Team, workspace *tfe.Workspace) (string, error) {
+	current, err := teamAccessFind(client, team, workspace)
+	if err != nil {
+		return "", err
+	}
+
+	if err := client.TeamAccess.Remove(context.Background(), current.ID); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s access of team %s removed", current.Access, team.Name), nil
+}
+
+// teamAccessFind returns the access of a team to a workspace
+func teamAccessFind(client *tfe.Client, team *tfe.Team, workspace *tfe.Workspace) (*tfe.TeamAccess, error) {
+	accesses, err := teamAccessListAll(client, workspace.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the team accesses of workspace %s\n%w", workspace.Name, err)
+	}
+
+	for _, ta := range accesses {
+		if ta.Team != nil && ta.Team.ID == team.ID {
+			return ta, nil
+		}
+	}
+
+	return nil, fmt.Errorf("team %s has no access to workspace %s\n%w", team.Name, workspace.Name, tfe.ErrResourceNotFound)
+}
+
+// teamAccessReport prints the team accesses of the workspaces given by --workspace, or of every workspace,
+// as a list or as a matrix
+func teamAccessReport(cmd *cobra.Command, client *tfe.Client, organization string, report string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("unable to get flag format\n%w", err)
+	}
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return fmt.Errorf("unable to get flag concurrency\n%w", err)
+	}
+
+	var team *tfe.Team
+	if cmd.Flags().Changed("team") || cmd.Flags().Changed("team-id") {
+		team, err = teamAccessTeam(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+	}
+
+	workspaces, err := teamAccessWorkspaces(cmd, client, organization)
+	if err != nil {
+		return err
+	}
+
+	teams, err := teamListAll(client, organization, tfe.TeamListOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to list teams\n%w", err)
+	}
+
+	teamNames := make(map[string]string)
+	for _, t := range teams {
+		teamNames[t.ID] = t.Name
+	}
+
+	accesses := make([][]*tfe.TeamAccess, len(workspaces))
+	errs := make([]error, len(workspaces))
+	aid.RunConcurrently(concurrency, len(workspaces), func(i int) {
+		accesses[i], errs[i] = teamAccessListAll(client, workspaces[i].ID)
+	})
+
+	var entries []model.TeamAccessEntry
+	var workspaceNames []string
+	for i, workspace := range workspaces {
+		// an incomplete report would be misleading in an access review
+		if errs[i] != nil {
+			return fmt.Errorf("unable to list the team accesses of workspace %s\n%w", workspace.Name, errs[i])
+		}
+
+		workspaceNames = append(workspaceNames, workspace.Name)
+		for _, ta := range accesses[i] {
+			if ta.Team == nil || (team != nil && ta.Team.ID != team.ID) {
+				continue
+			}
+
+			name, ok := teamNames[ta.Team.ID]
+			if !ok {
+				// the token can't see secret teams it isn't a member of
+				name = ta.Team.ID
+				teamNames[ta.Team.ID] = name
+			}
+			entries = append(entries, aid.NewTeamAccessEntry(workspace.Name, name, ta))
+		}
+	}
+
+	if report == "list" {
+		if format == "json" {
+			fmt.Println(aid.ToJSON(entries))
+		} else {
+			fmt.Print(aid.RenderTeamAccesses(entries))
+		}
+		return nil
+	}
+
+	var names []string
+	for _, name := range teamNames {
+		names = append(names, name)
+	}
+
+	out, err := aid.RenderTeamAccessMatrix(aid.NewTeamAccessMatrix(entries, names, workspaceNames), format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+	return nil
+}
+
+// teamAccessTeam returns the team given by --team or --team-id
+func teamAccessTeam(cmd *cobra.Command, client *tfe.Client, organization string) (*tfe.Team, error) {
+	id, err := cmd.Flags().GetString("team-id")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag team-id\n%w", err)
+	}
+
+	if id != "" {
+		team, err := teamRead(client, id)
+		if err != nil {
+			return nil, fmt.Errorf("team %s not found\n%w", id, err)
+		}
+		return team, nil
+	}
+
+	name, err := cmd.Flags().GetString("team")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag team\n%w", err)
+	}
+
+	return teamFindByName(client, organization, name)
+}
+
+// teamAccessWorkspaces returns the workspaces given by --workspace, or every workspace of the organization.
+// Names are read one by one, patterns are matched against every workspace.
+func teamAccessWorkspaces(cmd *cobra.Command, client *tfe.Client, organization string) ([]*tfe.Workspace, error) {
+	values, err := cmd.Flags().GetStringSlice("workspace")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get flag workspace\n%w", err)
+	}
+
+	patterns := len(values) == 0
+	for _, value := range values {
+		patterns = patterns || aid.IsWorkspacePattern(value)
+	}
+
+	if !patterns {
+		var workspaces []*tfe.Workspace
+		for _, name := range values {
+			workspace, err := workspaceRead(client, organization, name)
+			if err != nil {
+				return nil, fmt.Errorf("workspace %s not found\n%w", name, err)
+			}
+			workspaces = append(workspaces, workspace)
+		}
+		return workspaces, nil
+	}
+
+	all, err := workspaceListAll(client, organization, tfe.WorkspaceListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list workspaces\n%w", err)
+	}
+
+	if len(values) == 0 {
+		return all, nil
+	}
+
+	byName := make(map[string]*tfe.Workspace)
+	var names []string
+	for _, workspace := range all {
+		byName[workspace.Name] = workspace
+		names = append(names, workspace.Name)
+	}
+
+	matched, err := aid.MatchWorkspaceNames(names, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaces []*tfe.Workspace
+	for _, name := range matched {
+		workspaces = append(workspaces, byName[name])
+	}
+
+	return workspaces, nil
+}
+
+// teamAccessListAll pages through the team accesses of a workspace
+func teamAccessListAll(client *tfe.Client, workspaceID string) ([]*tfe.TeamAccess, error) {
+	var accesses []*tfe.TeamAccess
+	options := tfe.TeamAccessListOptions{ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100}, WorkspaceID: workspaceID}
+	for {
+		list, err := client.TeamAccess.List(context.Background(), &options)
+		if err != nil {
+			return nil, err
+		}
+
+		accesses = append(accesses, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return accesses, nil
+		}
+		options.PageNumber = list.NextPage
+	}
+}
@@ -18,7 +18,6 @@ package controller
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
@@ -42,8 +41,7 @@ var variableValidArgs = []string{
 func VariableCmd() *cobra.Command {
 	man, err := helper.GetManual("variable", variableValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -104,7 +102,10 @@ func variableRun(cmd *cobra.Command, args []string) error {
 	// https://www.terraform.io/docs/cloud/users-teams-organizations/api-tokens.html#team-api-tokens
 
 	token := dao.GetOrganizationToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	fArg := args[0]
 	switch fArg {
@@ -112,15 +113,22 @@ func variableRun(cmd *cobra.Command, args []string) error {
 		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
 
 		list, err := variableList(client, workspaceID, tfe.VariableListOptions{})
-		if err == nil && len(list.Items) > 0 {
-			aid.PrintVariableList(list)
-		} else {
+		if err != nil {
+			return fmt.Errorf("unable to list variables\n%w", err)
+		}
+
+		if len(list.Items) == 0 {
 			return fmt.Errorf("no variable was found")
 		}
 
+		aid.PrintVariableList(list)
+
 	case "create":
 		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
-		options := aid.GetVariableCreateOptions(cmd)
+		options, err := aid.GetVariableCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
 
 		variable, err := variableCreate(client, workspaceID, options)
 		if err == nil && variable.ID != "" {
@@ -143,7 +151,10 @@ func variableRun(cmd *cobra.Command, args []string) error {
 	case "update":
 		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
 		id := helper.GetCmdFlagString(cmd, "id")
-		options := aid.GetVariableUpdateOptions(cmd)
+		options, err := aid.GetVariableUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
 
 		variable, err := variableUpdate(client, workspaceID, id, options)
 		if err == nil && variable.ID != "" {
@@ -156,8 +167,8 @@ func variableRun(cmd *cobra.Command, args []string) error {
 		workspaceID := helper.GetCmdFlagString(cmd, "workspace-id")
 
 		list, err := variableList(client, workspaceID, tfe.VariableListOptions{})
-		if err != nil && len(list.Items) == 0 {
-			return fmt.Errorf("variable list is empty")
+		if err != nil {
+			return fmt.Errorf("unable to list variables\n%w", err)
 		}
 
 		found, err := aid.FindVariableByKey(list, cmd)
@@ -165,7 +176,10 @@ func variableRun(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		options := aid.GetVariableUpdateOptions(cmd)
+		options, err := aid.GetVariableUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
 		variable, err := variableUpdate(client, workspaceID, found.ID, options)
 
 		if err == nil && variable.ID != "" {
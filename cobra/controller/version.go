@@ -17,7 +17,6 @@ package controller
 
 import (
 	"fmt"
-	"os"
 	"runtime"
 
 	"github.com/awslabs/tecli/box"
@@ -30,8 +29,7 @@ import (
 func VersionCmd() *cobra.Command {
 	man, err := helper.GetManual("version", []string{})
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	return &cobra.Command{
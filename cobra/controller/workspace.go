@@ -18,12 +18,13 @@ package controller
 import (
 	"context"
 	"fmt"
-	"os"
+	"strings"
 
 	"github.com/awslabs/tecli/cobra/aid"
 	"github.com/awslabs/tecli/cobra/dao"
 	"github.com/awslabs/tecli/helper"
 	"github.com/hashicorp/go-tfe"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -43,14 +44,15 @@ var workspaceValidArgs = []string{
 	"unlock",
 	"force-unlock",
 	"assign-ssh-key",
-	"unassign-ssh-key"}
+	"unassign-ssh-key",
+	"outputs",
+	"graph"}
 
 // WorkspaceCmd command to display tecli current version
 func WorkspaceCmd() *cobra.Command {
 	man, err := helper.GetManual("workspace", workspaceValidArgs)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logrus.Fatal(err)
 	}
 
 	cmd := &cobra.Command{
@@ -93,6 +95,28 @@ func workspacePreRun(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+	case "outputs", "graph":
+		formats := aid.WorkspaceGraphFormats
+		if fArg == "outputs" {
+			if err := helper.ValidateCmdArgAndFlag(cmd, args, "workspace", fArg, "name"); err != nil {
+				return err
+			}
+			formats = aid.WorkspaceOutputFormats
+		}
+
+		format, err := aid.GetWorkspaceFormat(cmd, fArg)
+		if err != nil {
+			return err
+		}
+
+		if !helper.ContainsString(formats, format) {
+			return fmt.Errorf("invalid --format %q, valid values are: %s", format, strings.Join(formats, ", "))
+		}
+
+		if fArg == "graph" {
+			return runConcurrencyPreRun(cmd)
+		}
+
 	case "read-by-id",
 		"update-by-id",
 		"delete-by-id",
@@ -116,18 +140,33 @@ func workspacePreRun(cmd *cobra.Command, args []string) error {
 func workspaceRun(cmd *cobra.Command, args []string) error {
 
 	token := dao.GetOrganizationToken(profile)
-	client := aid.GetTFEClient(token)
+	client, err := aid.GetTFEClient(token)
+	if err != nil {
+		return err
+	}
 
 	fArg := args[0]
 	switch fArg {
 	case "list":
 		organization := dao.GetOrganization(profile)
-		options := aid.GetWorkspaceListOptions(cmd)
+		options, err := aid.GetWorkspaceListOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		project, err := projectFlag(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+		if project != nil {
+			options.ProjectID = project.ID
+		}
+
 		list, err := workspaceList(client, organization, options)
 		if err == nil {
 			aid.PrintWorkspaceList(list)
 		} else {
-			return fmt.Errorf("no workspace was found")
+			return fmt.Errorf("no workspace was found\n%w", err)
 		}
 	case "find-by-name":
 		name, err := cmd.Flags().GetString("name")
@@ -147,7 +186,16 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		fmt.Println(aid.ToJSON(w))
 	case "create":
 		organization := dao.GetOrganization(profile)
-		options := aid.GetWorkspaceCreateOptions(cmd)
+		options, err := aid.GetWorkspaceCreateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		options.Project, err = projectFlag(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
 		workspace, err := workspaceCreate(client, organization, options)
 
 		if err == nil && workspace.ID != "" {
@@ -187,7 +235,16 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		}
 
 		organization := dao.GetOrganization(profile)
-		options := aid.GetWorkspaceUpdateOptions(cmd)
+		options, err := aid.GetWorkspaceUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		options.Project, err = projectFlag(cmd, client, organization)
+		if err != nil {
+			return err
+		}
+
 		workspace, err := workspaceUpdate(client, organization, name, options)
 		if err == nil && workspace.ID != "" {
 			fmt.Println(aid.ToJSON(workspace))
@@ -200,7 +257,16 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetWorkspaceUpdateOptions(cmd)
+		options, err := aid.GetWorkspaceUpdateOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		options.Project, err = projectFlag(cmd, client, dao.GetOrganization(profile))
+		if err != nil {
+			return err
+		}
+
 		workspace, err := workspaceUpdateByID(client, id, options)
 		if err == nil && workspace.ID != "" {
 			fmt.Println(aid.ToJSON(workspace))
@@ -305,7 +371,10 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to get flag id\n%w", err)
 		}
 
-		options := aid.GetWorkspaceAssignSSHKeyOptions(cmd)
+		options, err := aid.GetWorkspaceAssignSSHKeyOptions(cmd)
+		if err != nil {
+			return err
+		}
 		workspace, err := workspaceAssignSSHKey(client, id, options)
 		if err != nil {
 			return err
@@ -316,6 +385,10 @@ func workspaceRun(cmd *cobra.Command, args []string) error {
 		}
 	case "unassign-ssh-key":
 		fmt.Println("unassign-ssh-key")
+	case "outputs":
+		return workspaceOutputs(cmd, client, dao.GetOrganization(profile))
+	case "graph":
+		return workspaceGraph(cmd, client, dao.GetOrganization(profile))
 	default:
 		return fmt.Errorf("unknown argument provided")
 	}
@@ -327,6 +400,26 @@ func workspaceList(client *tfe.Client, organization string, options tfe.Workspac
 	return client.Workspaces.List(context.Background(), organization, &options)
 }
 
+// workspaceListAll pages through every workspace of the organization
+func workspaceListAll(client *tfe.Client, organization string, options tfe.WorkspaceListOptions) ([]*tfe.Workspace, error) {
+	var workspaces []*tfe.Workspace
+
+	options.PageNumber = 1
+	options.PageSize = 100
+	for {
+		list, err := workspaceList(client, organization, options)
+		if err != nil {
+			return nil, err
+		}
+
+		workspaces = append(workspaces, list.Items...)
+		if list.Pagination == nil || list.Pagination.NextPage == 0 {
+			return workspaces, nil
+		}
+		options.PageNumber = list.Pagination.NextPage
+	}
+}
+
 // workspaceFindByName looks up a workspace by exact name using the
 // dedicated read endpoint, which avoids the 20-per-page pagination
 // limit of the list endpoint that caused issue #12.
@@ -406,3 +499,123 @@ func workspaceAssignSSHKey(client *tfe.Client, workspaceID string, options tfe.W
 func workspaceUnassignSSHKey(client *tfe.Client, workspaceID string) (*tfe.Workspace, error) {
 	return client.Workspaces.UnassignSSHKey(context.Background(), workspaceID)
 }
+
+// workspaceOutputs prints the outputs of the current state version of a workspace in the format given by --format
+func workspaceOutputs(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return fmt.Errorf("unable to get flag name\n%w", err)
+	}
+
+	format, err := aid.GetWorkspaceFormat(cmd, "outputs")
+	if err != nil {
+		return err
+	}
+
+	prefix, err := cmd.Flags().GetString("prefix")
+	if err != nil {
+		return fmt.Errorf("unable to get flag prefix\n%w", err)
+	}
+
+	sensitive, err := cmd.Flags().GetBool("sensitive")
+	if err != nil {
+		return fmt.Errorf("unable to get flag sensitive\n%w", err)
+	}
+
+	workspace, err := workspaceRead(client, organization, name)
+	if err != nil {
+		return fmt.Errorf("workspace %s not found\n%w", name, err)
+	}
+
+	list, err := client.StateVersionOutputs.ReadCurrent(context.Background(), workspace.ID)
+	if err != nil {
+		return fmt.Errorf("unable to read the outputs of workspace %s\n%w", name, err)
+	}
+
+	var outputs []*tfe.StateVersionOutput
+	var hidden []string
+	for _, o := range list.Items {
+		if o.Sensitive {
+			if !sensitive {
+				hidden = append(hidden, o.Name)
+				continue
+			}
+
+			// the outputs of a list leave the sensitive values out, reading the output alone gives its value
+			value, err := client.StateVersionOutputs.Read(context.Background(), o.ID)
+			if err != nil {
+				return fmt.Errorf("unable to read sensitive output %s\n%w", o.Name, err)
+			}
+			o = value
+		}
+		outputs = append(outputs, o)
+	}
+
+	if len(hidden) > 0 {
+		logrus.Infof("sensitive outputs left out, use --sensitive to include them: %s", strings.Join(hidden, ", "))
+	}
+
+	out, err := aid.RenderWorkspaceOutputs(aid.GetWorkspaceOutputValues(outputs), format, prefix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+	return nil
+}
+
+// workspaceGraph prints the run trigger graph of the organization, or with --name the part of it that
+// applying the workspace would trigger
+func workspaceGraph(cmd *cobra.Command, client *tfe.Client, organization string) error {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return fmt.Errorf("unable to get flag name\n%w", err)
+	}
+
+	format, err := aid.GetWorkspaceFormat(cmd, "graph")
+	if err != nil {
+		return err
+	}
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return fmt.Errorf("unable to get flag concurrency\n%w", err)
+	}
+
+	if name != "" {
+		if _, err := workspaceRead(client, organization, name); err != nil {
+			return fmt.Errorf("workspace %s not found\n%w", name, err)
+		}
+	}
+
+	graph, err := runTriggerGraph(client, organization, concurrency)
+	if err != nil {
+		return err
+	}
+
+	for _, cycle := range aid.FindRunTriggerCycles(graph) {
+		logrus.Warnf("run trigger cycle, an apply of any of these workspaces triggers itself: %s", strings.Join(cycle, " -> "))
+	}
+
+	if name != "" {
+		// the dot and mermaid documents are printed alone, even empty, so they can be piped to a renderer
+		triggered := aid.GetTriggeredWorkspaces(graph, name)
+		if len(triggered) == 0 && format == "tree" {
+			fmt.Printf("applying %s triggers no run\n", name)
+			return nil
+		}
+
+		if format == "tree" {
+			fmt.Printf("Triggered by applying %s: %s\n\n", name, strings.Join(triggered, ", "))
+		}
+		graph = aid.GetDownstreamRunTriggerGraph(graph, name)
+	}
+
+	out, err := aid.RenderRunTriggerGraph(graph, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+	return nil
+}
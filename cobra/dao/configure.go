@@ -136,6 +136,33 @@ func GetOrganizationToken(name string) string {
 	return cp.OrganizationToken
 }
 
+// GetProtectedPatterns return the protected workspace name patterns of a profile
+func GetProtectedPatterns(name string) []string {
+	cp, err := GetCredentialProfile(name)
+	if err != nil {
+		logrus.Debugf("unable to read protected workspaces from credentials\n%v", err)
+	}
+
+	return cp.Protected
+}
+
+// GetAuditSink return where audit entries are forwarded to, besides the local journal
+func GetAuditSink(name string) string {
+	// from ENV variable
+	sink := viper.GetString("AUDIT_SINK")
+	if sink != "" {
+		return sink
+	}
+
+	// from credentials file
+	cp, err := GetCredentialProfile(name)
+	if err != nil {
+		logrus.Debugf("unable to read audit sink from credentials\n%v", err)
+	}
+
+	return cp.AuditSink
+}
+
 // SaveCredentials saves the given credential onto the credentials file
 func SaveCredentials(credentials model.Credentials) error {
 	return helper.WriteInterfaceToFile(credentials, viper.ConfigFileUsed())
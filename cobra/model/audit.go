@@ -0,0 +1,31 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "time"
+
+// AuditEntry represents a single line of the local audit journal
+type AuditEntry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	User         string    `json:"user"`
+	Profile      string    `json:"profile"`
+	Organization string    `json:"organization,omitempty"`
+	Command      string    `json:"command"`
+	Resources    []string  `json:"resources,omitempty"`
+	Result       string    `json:"result"`
+	Error        string    `json:"error,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+}
@@ -29,4 +29,8 @@ type CredentialProfile struct {
 	UserToken         string `yaml:"userToken"`
 	TeamToken         string `yaml:"teamToken"`
 	OrganizationToken string `yaml:"organizationToken"`
+	// Protected lists workspace name patterns (e.g. *-prod) guarded against destructive operations
+	Protected []string `yaml:"protected,omitempty"`
+	// AuditSink forwards audit entries to syslog or to a file, e.g. syslog or file:/var/log/tecli.json
+	AuditSink string `yaml:"auditSink,omitempty"`
 }
@@ -0,0 +1,40 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// NotificationPayload is the body of a notification sent to a generic webhook
+type NotificationPayload struct {
+	PayloadVersion              int                   `json:"payload_version"`
+	NotificationConfigurationID string                `json:"notification_configuration_id"`
+	RunURL                      string                `json:"run_url"`
+	RunID                       string                `json:"run_id"`
+	RunMessage                  string                `json:"run_message"`
+	RunCreatedAt                string                `json:"run_created_at"`
+	RunCreatedBy                string                `json:"run_created_by"`
+	WorkspaceID                 string                `json:"workspace_id"`
+	WorkspaceName               string                `json:"workspace_name"`
+	OrganizationName            string                `json:"organization_name"`
+	Notifications               []NotificationMessage `json:"notifications"`
+}
+
+// NotificationMessage is a single notification of a payload
+type NotificationMessage struct {
+	Message      string `json:"message"`
+	Trigger      string `json:"trigger"`
+	RunStatus    string `json:"run_status"`
+	RunUpdatedAt string `json:"run_updated_at"`
+	RunUpdatedBy string `json:"run_updated_by"`
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// PlanJSON represents the parts of the Terraform JSON plan used by tecli
+type PlanJSON struct {
+	FormatVersion    string           `json:"format_version"`
+	TerraformVersion string           `json:"terraform_version"`
+	ResourceChanges  []ResourceChange `json:"resource_changes"`
+}
+
+// ResourceChange represents the planned change of a single resource
+type ResourceChange struct {
+	Address       string `json:"address"`
+	ModuleAddress string `json:"module_address,omitempty"`
+	Mode          string `json:"mode"`
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	ProviderName  string `json:"provider_name"`
+	Change        Change `json:"change"`
+}
+
+// Change represents the actions planned for a resource
+type Change struct {
+	Actions []string `json:"actions"`
+}
+
+// PlanSummary represents the changes of a plan grouped by module and provider
+type PlanSummary struct {
+	Totals map[string]int     `json:"totals"`
+	Groups []PlanSummaryGroup `json:"groups"`
+}
+
+// PlanSummaryGroup represents the changes of a plan in a single module for a single provider
+type PlanSummaryGroup struct {
+	Module   string               `json:"module"`
+	Provider string               `json:"provider"`
+	Changes  []PlanResourceAction `json:"changes"`
+}
+
+// PlanResourceAction represents the action planned for a single resource
+type PlanResourceAction struct {
+	Address string `json:"address"`
+	Action  string `json:"action"`
+}
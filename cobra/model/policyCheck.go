@@ -0,0 +1,39 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// SentinelResult is the detailed result of a policy check, as returned in its sentinel attribute
+type SentinelResult struct {
+	SchemaVersion string                       `json:"schema-version"`
+	Data          map[string]SentinelPolicySet `json:"data"`
+}
+
+// SentinelPolicySet is the result of the policies of a policy set
+type SentinelPolicySet struct {
+	CanOverride bool             `json:"can-override"`
+	Error       interface{}      `json:"error"`
+	Policies    []SentinelPolicy `json:"policies"`
+	Result      bool             `json:"result"`
+}
+
+// SentinelPolicy is the result of a single policy
+type SentinelPolicy struct {
+	AllowedFailure   bool        `json:"allowed-failure"`
+	EnforcementLevel string      `json:"enforcement-level"`
+	Error            interface{} `json:"error"`
+	Policy           string      `json:"policy"`
+	Result           bool        `json:"result"`
+}
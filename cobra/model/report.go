@@ -0,0 +1,77 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "time"
+
+// RunReport is the data rendered by the run report templates
+type RunReport struct {
+	Organization string
+	Run          RunReportRun
+	Workspace    RunReportWorkspace
+	Plan         RunReportPlan
+	Changes      PlanSummary
+	CostEstimate *RunReportCostEstimate
+	PolicyChecks []RunReportPolicyCheck
+}
+
+// RunReportRun represents the run of a report
+type RunReportRun struct {
+	ID        string
+	Status    string
+	Message   string
+	Source    string
+	IsDestroy bool
+	CreatedAt time.Time
+	URL       string
+}
+
+// RunReportWorkspace represents the workspace of a report
+type RunReportWorkspace struct {
+	ID   string
+	Name string
+	URL  string
+}
+
+// RunReportPlan represents the plan counts of a report
+type RunReportPlan struct {
+	ID           string
+	Status       string
+	HasChanges   bool
+	Additions    int
+	Changes      int
+	Destructions int
+	Imports      int
+}
+
+// RunReportCostEstimate represents the cost estimate of a report
+type RunReportCostEstimate struct {
+	Status              string
+	PriorMonthlyCost    string
+	ProposedMonthlyCost string
+	DeltaMonthlyCost    string
+}
+
+// RunReportPolicyCheck represents a policy check of a report
+type RunReportPolicyCheck struct {
+	ID             string
+	Scope          string
+	Status         string
+	Passed         int
+	AdvisoryFailed int
+	SoftFailed     int
+	HardFailed     int
+}
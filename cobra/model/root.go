@@ -31,6 +31,9 @@ type App struct {
 	LogsFileType               string
 	LogsFilePath               string
 	LogsFilePermissions        os.FileMode
+	AuditFileName              string
+	AuditFileType              string
+	AuditFilePath              string
 
 	WorkingDir string
 }
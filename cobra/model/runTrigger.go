@@ -0,0 +1,34 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// RunTriggerGraph is the directed graph of the run triggers of an organization, an edge going from the
+// workspace whose applies trigger runs to the workspace where the runs are queued
+type RunTriggerGraph struct {
+	// Workspaces are the names of the workspaces with a run trigger, sorted
+	Workspaces []string
+	// Triggers maps a workspace name to the sorted names of the workspaces its applies trigger
+	Triggers map[string][]string
+}
+
+// RunTriggerEntry is a run trigger with the direction it has for the workspace it was listed for
+type RunTriggerEntry struct {
+	ID        string `json:"id"`
+	Direction string `json:"direction"`
+	Source    string `json:"source"`
+	Workspace string `json:"workspace"`
+	CreatedAt string `json:"created-at"`
+}
@@ -0,0 +1,56 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "time"
+
+// StateMigrationManifest maps state files to the workspaces they are pushed to
+type StateMigrationManifest struct {
+	States []StateMigrationSource `yaml:"states"`
+}
+
+// StateMigrationSource is either a single state file pushed to a workspace, or a directory
+// tree whose state files are pushed to workspaces named after their directory
+type StateMigrationSource struct {
+	File            string `yaml:"file"`
+	Workspace       string `yaml:"workspace"`
+	Dir             string `yaml:"dir"`
+	WorkspacePrefix string `yaml:"workspace-prefix"`
+}
+
+// StateMigrationItem is a state file to push and its workspace
+type StateMigrationItem struct {
+	File      string
+	Workspace string
+}
+
+// StateMigrationProgress is the content of the progress file of a migration
+type StateMigrationProgress struct {
+	Entries []StateMigrationProgressEntry `json:"entries"`
+}
+
+// StateMigrationProgressEntry records the outcome of the last push of a state file
+type StateMigrationProgressEntry struct {
+	File           string    `json:"file"`
+	Workspace      string    `json:"workspace"`
+	MD5            string    `json:"md5"`
+	Status         string    `json:"status"`
+	StateVersionID string    `json:"state-version-id,omitempty"`
+	Serial         int64     `json:"serial"`
+	Resources      int       `json:"resources"`
+	Error          string    `json:"error,omitempty"`
+	UpdatedAt      time.Time `json:"updated-at"`
+}
@@ -0,0 +1,38 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// TeamAccessEntry is the access of a team to a workspace, with their names
+type TeamAccessEntry struct {
+	Workspace        string `json:"workspace"`
+	Team             string `json:"team"`
+	ID               string `json:"id"`
+	Access           string `json:"access"`
+	Runs             string `json:"runs"`
+	Variables        string `json:"variables"`
+	StateVersions    string `json:"state-versions"`
+	SentinelMocks    string `json:"sentinel-mocks"`
+	WorkspaceLocking bool   `json:"workspace-locking"`
+	RunTasks         bool   `json:"run-tasks"`
+}
+
+// TeamAccessMatrix is the access of every team to every workspace of an organization
+type TeamAccessMatrix struct {
+	Teams      []string
+	Workspaces []string
+	// Access maps a workspace name to the access of each team name, a missing team having no access
+	Access map[string]map[string]string
+}
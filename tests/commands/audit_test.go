@@ -0,0 +1,107 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/controller"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactArgs(t *testing.T) {
+	args := []string{"tecli", "variable", "create", "--key", "password", "--value", "s3cr3t", "--team-token=abc", "--sensitive=true"}
+	redacted := aid.RedactArgs(args)
+	assert.Equal(t, []string{"tecli", "variable", "create", "--key", "password", "--value", aid.RedactedValue, "--team-token=" + aid.RedactedValue, "--sensitive=true"}, redacted)
+	// the original command line is left untouched
+	assert.Equal(t, "s3cr3t", args[6])
+
+	redacted = aid.RedactArgs([]string{"tecli", "run", "create", "--var", "db_password=s3cr3t", "--var=token=abc"})
+	assert.Equal(t, []string{"tecli", "run", "create", "--var", aid.RedactedValue, "--var=" + aid.RedactedValue}, redacted)
+
+	redacted = aid.RedactArgs([]string{"tecli", "notification-configuration", "create", "--name", "slack", "--url", "https://hooks.slack.com/services/T0/B0/s3cr3t"})
+	assert.Equal(t, []string{"tecli", "notification-configuration", "create", "--name", "slack", "--url", aid.RedactedValue}, redacted)
+}
+
+func TestIsMutatingArgument(t *testing.T) {
+	for _, arg := range []string{"create", "delete-all", "force-cancel-all", "update-by-key", "force-unlock", "remove-vcs-connection", "lock", "set-parameter"} {
+		assert.True(t, aid.IsMutatingArgument(arg), arg)
+	}
+
+	for _, arg := range []string{"list", "read", "read-with-options", "find-by-name", "logs", "list-parameters"} {
+		assert.False(t, aid.IsMutatingArgument(arg), arg)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	since, err := aid.ParseSince("24h", now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), since)
+
+	since, err = aid.ParseSince("2025-12-31T00:00:00Z", now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), since)
+
+	since, err = aid.ParseSince("", now)
+	assert.Nil(t, err)
+	assert.True(t, since.IsZero())
+
+	_, err = aid.ParseSince("yesterday", now)
+	assert.NotNil(t, err)
+}
+
+func TestAuditBulkRunAction(t *testing.T) {
+	fakeTFE(t, map[string]string{
+		"GET /api/v2/workspaces/ws-1":            `{"data":{"id":"ws-1","type":"workspaces","attributes":{"name":"app"}}}`,
+		"GET /api/v2/workspaces/ws-1/runs":       `{"data":[{"id":"run-1","type":"runs","attributes":{"status":"planning","actions":{"is-cancelable":true}}},{"id":"run-2","type":"runs","attributes":{"status":"pending","actions":{"is-cancelable":true}}}]}`,
+		"POST /api/v2/runs/run-1/actions/cancel": ``,
+		"POST /api/v2/runs/run-2/actions/cancel": ``,
+	})
+
+	command, _, err := executeCommandC(controller.RunCmd(), []string{"run", "cancel-all", "--workspace-id", "ws-1"})
+	assert.NoError(t, err)
+	controller.AuditCommand(command, err)
+
+	// the runs the bulk action acted on are journaled along with the workspace
+	entries, err := aid.ReadAuditEntries(time.Time{}, "run-2")
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, []string{"workspace-id=ws-1", "run=run-1", "run=run-2"}, entries[0].Resources)
+		assert.Equal(t, "success", entries[0].Result)
+	}
+}
+
+func TestAuditClientFailure(t *testing.T) {
+	fakeTFE(t, map[string]string{})
+	// nothing listens on the discard port
+	t.Setenv("TFE_ADDRESS", "http://127.0.0.1:9")
+
+	command, _, err := executeCommandC(controller.WorkspaceCmd(), []string{"workspace", "delete", "--name", "app"})
+	assert.Error(t, err)
+	controller.AuditCommand(command, err)
+
+	// a command whose API client can't be set up is journaled as a failure rather than exiting
+	entries, err := aid.ReadAuditEntries(time.Time{}, "app")
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "failure", entries[0].Result)
+		assert.Contains(t, entries[0].Error, "unable to get terraform cloud api client")
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCostEstimate(t *testing.T) {
+	ce := &tfe.CostEstimate{ID: "ce-1", Status: tfe.CostEstimateFinished, PriorMonthlyCost: "10.0", ProposedMonthlyCost: "25.5", DeltaMonthlyCost: "15.5", ResourcesCount: 7, MatchedResourcesCount: 5, UnmatchedResourcesCount: 2}
+
+	out := aid.RenderCostEstimate(ce)
+	assert.Regexp(t, `PROPOSED MONTHLY COST\s+\$25\.50`, out)
+	assert.Regexp(t, `DELTA\s+\+\$15\.50`, out)
+	assert.Regexp(t, `RESOURCES\s+7 \(5 matched, 2 unmatched\)`, out)
+
+	assert.Equal(t, "-$3.20", aid.FormatCostDelta("-3.2"))
+	assert.Equal(t, "unknown", aid.FormatCost("unknown"))
+}
+
+func TestCheckCostPreconditions(t *testing.T) {
+	maxDelta := 10.0
+	preconditions := aid.ApplyPreconditions{MaxChanges: -1, MaxDelta: &maxDelta}
+
+	assert.Empty(t, aid.CheckCostPreconditions(preconditions, &tfe.CostEstimate{Status: tfe.CostEstimateFinished, DeltaMonthlyCost: "-50"}))
+	assert.Len(t, aid.CheckCostPreconditions(preconditions, &tfe.CostEstimate{Status: tfe.CostEstimateFinished, DeltaMonthlyCost: "15.5"}), 1)
+	assert.Len(t, aid.CheckCostPreconditions(preconditions, &tfe.CostEstimate{Status: tfe.CostEstimateErrored}), 1)
+	assert.Len(t, aid.CheckCostPreconditions(preconditions, nil), 1)
+	assert.Empty(t, aid.CheckCostPreconditions(aid.ApplyPreconditions{MaxChanges: -1}, nil))
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		kind string
+		code int
+	}{
+		{tfe.ErrUnauthorized, aid.ErrorKindUnauthorized, aid.ExitCodeUnauthorized},
+		{tfe.ErrResourceNotFound, aid.ErrorKindNotFound, aid.ExitCodeNotFound},
+		{tfe.ErrWorkspaceLocked, aid.ErrorKindConflict, aid.ExitCodeConflict},
+		{fmt.Errorf("run run-123 not found\n%w", tfe.ErrResourceNotFound), aid.ErrorKindNotFound, aid.ExitCodeNotFound},
+		{fmt.Errorf("unable to lock workspace\n%w", tfe.ErrWorkspaceLockedByRun), aid.ErrorKindConflict, aid.ExitCodeConflict},
+		{fmt.Errorf("unable to create run\n%w", tfe.ErrRequiredWorkspace), aid.ErrorKindValidation, aid.ExitCodeValidation},
+		{errors.New("invalid value for --error-format"), aid.ErrorKindValidation, aid.ExitCodeValidation},
+		{errors.New("something went wrong"), aid.ErrorKindError, aid.ExitCodeError},
+		{fmt.Errorf("unable to read workspace\n%w", tfe.ErrInvalidIncludeValue), aid.ErrorKindValidation, aid.ExitCodeValidation},
+		{fmt.Errorf("unable to create workspace\n%w", errors.New("invalid attribute\n\nName has already been taken")), aid.ErrorKindValidation, aid.ExitCodeValidation},
+		{errors.New("forbidden"), aid.ErrorKindUnauthorized, aid.ExitCodeUnauthorized},
+		{fmt.Errorf("unable to list runs\n%w", errors.New("429 Too Many Requests")), aid.ErrorKindRateLimited, aid.ExitCodeRateLimited},
+		{errors.New("500 Internal Server Error"), aid.ErrorKindError, aid.ExitCodeError},
+	}
+
+	for _, test := range tests {
+		classified := aid.ClassifyError(test.err)
+		assert.Equal(t, test.kind, classified.Kind, test.err.Error())
+		assert.Equal(t, test.code, classified.Code, test.err.Error())
+		assert.Equal(t, test.err.Error(), classified.Message)
+	}
+}
+
+func TestClassifyErrorKeepsClassifiedErrors(t *testing.T) {
+	err := &aid.Error{Code: aid.ExitCodeConflict, Kind: aid.ErrorKindConflict, Message: "already classified"}
+	wrapped := fmt.Errorf("wrapped\n%w", err)
+	assert.Equal(t, err, aid.ClassifyError(wrapped))
+}
+
+func TestClassifyAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/ping":
+			w.Header().Set("TFP-API-Version", "2.5")
+			w.WriteHeader(http.StatusNoContent)
+		case "/api/v2/organizations/acme/workspaces":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"errors":[{"status":"422","title":"invalid attribute","detail":"Name has already been taken"}]}`))
+		case "/api/v2/organizations/acme/workspaces/included":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"status":"400","title":"Invalid include parameter"}]}`))
+		case "/api/v2/organizations/acme/workspaces/private":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("Forbidden"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := tfe.NewClient(&tfe.Config{Address: server.URL, Token: "token"})
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	_, err = client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{Name: tfe.String("taken")})
+	classified := aid.ClassifyError(fmt.Errorf("unable to create workspace\n%w", err))
+	assert.Equal(t, aid.ExitCodeValidation, classified.Code)
+	assert.Equal(t, "unable to create workspace\ninvalid attribute\n\nName has already been taken", classified.Message)
+
+	_, err = client.Workspaces.ReadWithOptions(ctx, "acme", "included", &tfe.WorkspaceReadOptions{Include: []tfe.WSIncludeOpt{tfe.WSOrganization}})
+	assert.Equal(t, aid.ExitCodeValidation, aid.ClassifyError(err).Code)
+
+	_, err = client.Workspaces.Read(ctx, "acme", "private")
+	classified = aid.ClassifyError(err)
+	assert.Equal(t, aid.ExitCodeUnauthorized, classified.Code)
+	assert.Equal(t, http.StatusForbidden, classified.HTTPStatus)
+
+	_, err = client.Workspaces.Read(ctx, "acme", "missing")
+	assert.Equal(t, aid.ExitCodeNotFound, aid.ClassifyError(err).Code)
+}
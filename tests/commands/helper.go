@@ -3,7 +3,11 @@ package commands
 
 import (
 	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/awslabs/tecli/cobra/controller"
@@ -44,6 +48,78 @@ func executeCommandC(cmd *cobra.Command, args []string) (command *cobra.Command,
 	return command, stdout, err
 }
 
+// executeCommandStdout executes the given command and returns what it wrote to the process stdout, where
+// the commands print their results
+func executeCommandStdout(cmd *cobra.Command, args []string) (stdout string, err error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	original := os.Stdout
+	os.Stdout = w
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&buf, r)
+		close(done)
+	}()
+
+	_, _, err = executeCommandC(cmd, args)
+
+	w.Close()
+	os.Stdout = original
+	<-done
+
+	return buf.String(), err
+}
+
+/* FAKE API */
+
+// fakeTFE starts a fake Terraform Cloud API answering each "METHOD /api/v2/path" route with its JSON:API body,
+// and points tecli at it with a credentials file, as a configured profile would
+func fakeTFE(t *testing.T, routes map[string]string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/ping" {
+			w.Header().Set("TFP-API-Version", "2.5")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		// a route may be given for a page of a list, e.g. "GET /api/v2/workspaces/ws-1/runs?page[number]=2"
+		body, found := routes[r.Method+" "+r.URL.Path+"?page[number]="+r.URL.Query().Get("page[number]")]
+		if !found {
+			body, found = routes[r.Method+" "+r.URL.Path]
+		}
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	configDir := t.TempDir()
+	credentials := []byte("profiles:\n- name: default\n  organization: acme\n")
+	if err := os.MkdirAll(filepath.Join(configDir, "tecli"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "tecli", "credentials.yaml"), credentials, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// a previous test may have left the working directory removed, tecli needs one
+	t.Chdir(configDir)
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("TFE_ADDRESS", server.URL)
+	t.Setenv("TFC_ORGANIZATION", "acme")
+	t.Setenv("TFC_TEAM_TOKEN", "team-token")
+	t.Setenv("TFC_ORGANIZATION_TOKEN", "organization-token")
+}
+
 /* TFE */
 
 // GetTFEClient returns a new terraform api client given a token
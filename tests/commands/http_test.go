@@ -0,0 +1,75 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactJSON(t *testing.T) {
+	payload := `{"data":[` +
+		`{"type":"vars","attributes":{"key":"password","value":"s3cr3t","sensitive":true}},` +
+		`{"type":"vars","attributes":{"key":"region","value":"us-east-1","sensitive":false}},` +
+		`{"type":"authentication-tokens","attributes":{"token":"abc.atlasv1.xyz"}}]}`
+
+	redacted, err := aid.RedactJSON([]byte(payload))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(redacted), "s3cr3t")
+	assert.NotContains(t, string(redacted), "abc.atlasv1.xyz")
+	assert.Contains(t, string(redacted), "us-east-1")
+
+	run := `{"data":{"type":"runs","attributes":{"message":"m","variables":[{"key":"db_password","value":"\"hunter2\""}]}}}`
+	redacted, err = aid.RedactJSON([]byte(run))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(redacted), "hunter2")
+	assert.Contains(t, string(redacted), "db_password")
+
+	notification := `{"data":{"type":"notification-configurations","attributes":{"name":"slack","url":"https://hooks.slack.com/services/T0/B0/s3cr3t"}}}`
+	redacted, err = aid.RedactJSON([]byte(notification))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(redacted), "hooks.slack.com")
+	assert.Contains(t, string(redacted), "slack")
+
+	_, err = aid.RedactJSON([]byte("not json"))
+	assert.NotNil(t, err)
+}
+
+func TestLoggingRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: aid.NewLoggingRoundTripper(nil)}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Authorization", "Bearer token")
+
+	resp, err := client.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+
+	headers := aid.RedactHeaders(req.Header)
+	assert.Equal(t, aid.RedactedValue, headers.Get("Authorization"))
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+}
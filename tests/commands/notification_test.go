@@ -0,0 +1,89 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+const notificationPayload = `{"payload_version":1,"notification_configuration_id":"nc-1","run_id":"run-1","run_message":"deploy","run_created_by":"alice","workspace_id":"ws-1","workspace_name":"app-dev","organization_name":"acme","notifications":[{"message":"Run Errored","trigger":"run:errored","run_status":"errored"}]}`
+
+func TestNotificationSignature(t *testing.T) {
+	body := []byte(notificationPayload)
+	signature := aid.SignNotification("s3cret", body)
+
+	assert.Len(t, signature, 128)
+	assert.True(t, aid.VerifyNotificationSignature("s3cret", body, signature))
+	assert.False(t, aid.VerifyNotificationSignature("other", body, signature))
+	assert.False(t, aid.VerifyNotificationSignature("s3cret", body, "not-hex"))
+}
+
+func TestNotificationHandler(t *testing.T) {
+	var out bytes.Buffer
+	handler := aid.NewNotificationHandler("s3cret", &out)
+
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(notificationPayload))
+	request.Header.Set(aid.NotificationSignatureHeader, aid.SignNotification("s3cret", []byte(notificationPayload)))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Regexp(t, `SIGNATURE\s+valid`, out.String())
+	assert.Regexp(t, `WORKSPACE\s+acme/app-dev \(ws-1\)`, out.String())
+	assert.Regexp(t, `RUN:ERRORED\s+errored`, out.String())
+
+	request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(notificationPayload))
+	request.Header.Set(aid.NotificationSignatureHeader, aid.SignNotification("other", []byte(notificationPayload)))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Contains(t, out.String(), "INVALID")
+
+	request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", aid.MaxNotificationBodySize+1)))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+}
+
+func TestNotificationCreateOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetNotificationFlags(cmd)
+	assert.Nil(t, cmd.Flags().Set("name", "errors"))
+	assert.Nil(t, cmd.Flags().Set("destination-type", "slack"))
+	assert.Nil(t, cmd.Flags().Set("trigger", "run:errored,run:needs_attention"))
+
+	_, err := aid.GetNotificationCreateOptions(cmd)
+	assert.EqualError(t, err, "--url is required by the slack destination")
+
+	assert.Nil(t, cmd.Flags().Set("url", "https://hooks.slack.com/services/x"))
+	options, err := aid.GetNotificationCreateOptions(cmd)
+	assert.Nil(t, err)
+	assert.True(t, *options.Enabled)
+	assert.Len(t, options.Triggers, 2)
+	assert.Nil(t, options.Token)
+	assert.Nil(t, options.EmailAddresses)
+
+	assert.Nil(t, cmd.Flags().Set("token", "s3cret"))
+	_, err = aid.GetNotificationCreateOptions(cmd)
+	assert.Error(t, err)
+}
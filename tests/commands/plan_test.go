@@ -0,0 +1,77 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/controller"
+	"github.com/stretchr/testify/assert"
+)
+
+const planJSONFixture = `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "module.db.aws_db_instance.main", "module_address": "module.db", "provider_name": "registry.terraform.io/hashicorp/aws", "change": {"actions": ["delete", "create"]}},
+    {"address": "aws_s3_bucket.logs", "provider_name": "registry.terraform.io/hashicorp/aws", "change": {"actions": ["create"]}},
+    {"address": "aws_iam_role.ci", "provider_name": "registry.terraform.io/hashicorp/aws", "change": {"actions": ["no-op"]}},
+    {"address": "random_id.suffix", "provider_name": "registry.terraform.io/hashicorp/random", "change": {"actions": ["delete"]}}
+  ]
+}`
+
+func TestPlanSummaryGroups(t *testing.T) {
+	plan, err := aid.ParsePlanJSON([]byte(planJSONFixture))
+	assert.Nil(t, err)
+
+	summary := aid.GetPlanSummary(plan)
+	assert.Equal(t, map[string]int{"create": 1, "update": 0, "replace": 1, "delete": 1}, summary.Totals)
+	assert.Len(t, summary.Groups, 3)
+
+	assert.Equal(t, "root", summary.Groups[0].Module)
+	assert.Equal(t, "hashicorp/aws", summary.Groups[0].Provider)
+	assert.Equal(t, "aws_s3_bucket.logs", summary.Groups[0].Changes[0].Address)
+
+	assert.Equal(t, "module.db", summary.Groups[2].Module)
+	assert.Equal(t, "replace", summary.Groups[2].Changes[0].Action)
+}
+
+func TestRenderPlanSummary(t *testing.T) {
+	plan, err := aid.ParsePlanJSON([]byte(planJSONFixture))
+	assert.Nil(t, err)
+	summary := aid.GetPlanSummary(plan)
+
+	for _, format := range aid.PlanSummaryFormats {
+		out, err := aid.RenderPlanSummary(summary, format)
+		assert.Nil(t, err)
+		assert.Contains(t, out, "module.db.aws_db_instance.main")
+		assert.NotContains(t, out, "aws_iam_role.ci")
+	}
+
+	_, err = aid.RenderPlanSummary(summary, "yaml")
+	assert.NotNil(t, err)
+}
+
+func TestPlanJSONStdout(t *testing.T) {
+	fakeTFE(t, map[string]string{
+		"GET /api/v2/plans/plan-1/json-output": planJSONFixture,
+	})
+
+	// stdout holds the JSON plan alone, so it can be piped to jq or redirected
+	out, err := executeCommandStdout(controller.PlanCmd(), []string{"plan", "json", "--id", "plan-1"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, planJSONFixture, out)
+}
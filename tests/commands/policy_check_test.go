@@ -0,0 +1,62 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPolicyChecks(t *testing.T) {
+	sentinel := map[string]interface{}{
+		"schema-version": "1.0.0",
+		"data": map[string]interface{}{
+			"org-policies": map[string]interface{}{
+				"can-override": true,
+				"result":       false,
+				"policies": []interface{}{
+					map[string]interface{}{"policy": "org-policies/restrict-instance-type", "allowed-failure": false, "result": false},
+					map[string]interface{}{"policy": "org-policies/require-tags", "allowed-failure": true, "result": true},
+					map[string]interface{}{"policy": "org-policies/cost", "enforcement-level": "soft-mandatory", "error": "timeout", "result": false},
+				},
+			},
+			"security": map[string]interface{}{
+				"can-override": false,
+				"result":       false,
+				"policies": []interface{}{
+					map[string]interface{}{"policy": "security/deny-public-buckets", "allowed-failure": false, "result": false},
+					map[string]interface{}{"policy": "security/require-encryption", "allowed-failure": false, "result": true},
+				},
+			},
+		},
+	}
+	checks := []*tfe.PolicyCheck{
+		{ID: "polchk-1", Scope: tfe.PolicyScopeOrganization, Status: tfe.PolicySoftFailed, Result: &tfe.PolicyResult{Sentinel: sentinel}},
+		{ID: "polchk-2", Scope: tfe.PolicyScopeWorkspace, Status: tfe.PolicyQueued},
+	}
+
+	out, err := aid.RenderPolicyChecks(checks)
+	assert.NoError(t, err)
+	assert.Regexp(t, `polchk-1\s+organization\s+soft_failed\s+org-policies/restrict-instance-type\s+soft-mandatory\s+failed`, out)
+	assert.Regexp(t, `org-policies/require-tags\s+advisory\s+passed`, out)
+	assert.Regexp(t, `org-policies/cost\s+soft-mandatory\s+errored`, out)
+	assert.Regexp(t, `security/deny-public-buckets\s+unknown\s+failed`, out)
+	assert.Regexp(t, `security/require-encryption\s+unknown\s+passed`, out)
+	assert.Regexp(t, `polchk-2\s+workspace\s+queued\s+-\s+-\s+-`, out)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyCreateOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetPolicyFlags(cmd)
+	assert.Nil(t, cmd.Flags().Set("name", "restrict-instances"))
+	assert.Nil(t, cmd.Flags().Set("enforcement-level", "soft-mandatory"))
+
+	options, err := aid.GetPolicyCreateOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "restrict-instances", *options.Name)
+	assert.Equal(t, tfe.Sentinel, options.Kind)
+	assert.Equal(t, tfe.EnforcementSoft, *options.EnforcementLevel)
+	assert.Nil(t, options.Query)
+
+	// soft-mandatory is a Sentinel level, and an OPA policy needs a query
+	assert.Nil(t, cmd.Flags().Set("kind", "opa"))
+	_, err = aid.GetPolicyCreateOptions(cmd)
+	assert.Error(t, err)
+
+	assert.Nil(t, cmd.Flags().Set("enforcement-level", "mandatory"))
+	_, err = aid.GetPolicyCreateOptions(cmd)
+	assert.Error(t, err)
+
+	assert.Nil(t, cmd.Flags().Set("query", "data.terraform.main.deny"))
+	options, err = aid.GetPolicyCreateOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, tfe.OPA, options.Kind)
+	assert.Equal(t, "data.terraform.main.deny", *options.Query)
+}
+
+func TestPolicyUpdateOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetPolicyFlags(cmd)
+	assert.Nil(t, cmd.Flags().Set("description", "instance sizes"))
+
+	options, err := aid.GetPolicyUpdateOptions(cmd, tfe.Sentinel)
+	assert.Nil(t, err)
+	assert.Equal(t, "instance sizes", *options.Description)
+	assert.Nil(t, options.EnforcementLevel)
+
+	assert.Nil(t, cmd.Flags().Set("enforcement-level", "hard-mandatory"))
+	_, err = aid.GetPolicyUpdateOptions(cmd, tfe.OPA)
+	assert.Error(t, err)
+
+	level := aid.GetPolicyEnforcement(&tfe.Policy{Enforce: []*tfe.Enforcement{{Path: "deny.rego", Mode: tfe.EnforcementMandatory}}})
+	assert.Equal(t, tfe.EnforcementMandatory, level)
+}
+
+func TestPolicySetOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetPolicySetFlags(cmd)
+	assert.Nil(t, cmd.Flags().Set("name", "baseline"))
+	assert.Nil(t, cmd.Flags().Set("global", "true"))
+
+	options, err := aid.GetPolicySetCreateOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "baseline", *options.Name)
+	assert.True(t, *options.Global)
+	assert.Nil(t, options.Description)
+	assert.Nil(t, options.VCSRepo)
+
+	// the policies path is a directory of the VCS repository, and only OPA policy sets can be overridden
+	assert.Nil(t, cmd.Flags().Set("policies-path", "sentinel"))
+	_, err = aid.GetPolicySetCreateOptions(cmd)
+	assert.Error(t, err)
+
+	assert.Nil(t, cmd.Flags().Set("vcs-repo-identifier", "org/policies"))
+	update, err := aid.GetPolicySetUpdateOptions(cmd, tfe.Sentinel)
+	assert.Nil(t, err)
+	assert.Equal(t, "org/policies", *update.VCSRepo.Identifier)
+	assert.Equal(t, "sentinel", *update.PoliciesPath)
+
+	assert.Nil(t, cmd.Flags().Set("overridable", "true"))
+	_, err = aid.GetPolicySetUpdateOptions(cmd, tfe.Sentinel)
+	assert.Error(t, err)
+}
+
+func TestPolicySetDir(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetPolicySetFlags(cmd)
+
+	dir := t.TempDir()
+	assert.Nil(t, cmd.Flags().Set("dir", dir))
+	_, err := aid.GetPolicySetDir(cmd)
+	assert.Error(t, err)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "sentinel.hcl"), []byte(`policy "restrict" {}`), 0644))
+	got, err := aid.GetPolicySetDir(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, dir, got)
+}
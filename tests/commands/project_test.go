@@ -0,0 +1,51 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetProjectFlags(cmd)
+	assert.Nil(t, cmd.Flags().Set("name", "platform"))
+
+	options, err := aid.GetProjectCreateOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "platform", options.Name)
+	assert.Nil(t, options.Description)
+
+	update, err := aid.GetProjectUpdateOptions(cmd)
+	assert.Nil(t, err)
+	assert.Nil(t, update.Name)
+	assert.Nil(t, update.Description)
+
+	// an empty description given explicitly clears it
+	assert.Nil(t, cmd.Flags().Set("new-name", "platform-eng"))
+	assert.Nil(t, cmd.Flags().Set("description", ""))
+	update, err = aid.GetProjectUpdateOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "platform-eng", *update.Name)
+	assert.Equal(t, "", *update.Description)
+
+	assert.True(t, aid.IsProjectID("prj-XXXXXXXX"))
+	assert.False(t, aid.IsProjectID("platform"))
+}
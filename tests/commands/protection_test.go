@@ -0,0 +1,62 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/controller"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchProtectedPattern(t *testing.T) {
+	patterns := []string{"*-prod", "billing-*"}
+
+	pattern, protected := aid.MatchProtectedPattern(patterns, "network-prod")
+	assert.True(t, protected)
+	assert.Equal(t, "*-prod", pattern)
+
+	pattern, protected = aid.MatchProtectedPattern(patterns, "billing-dev")
+	assert.True(t, protected)
+	assert.Equal(t, "billing-*", pattern)
+
+	_, protected = aid.MatchProtectedPattern(patterns, "network-dev")
+	assert.False(t, protected)
+
+	_, protected = aid.MatchProtectedPattern(nil, "network-prod")
+	assert.False(t, protected)
+}
+
+func TestMatchProtectedPatternInvalid(t *testing.T) {
+	_, protected := aid.MatchProtectedPattern([]string{"[-prod"}, "network-dev")
+	assert.True(t, protected)
+}
+
+func TestIsProtectedOperationRunCreate(t *testing.T) {
+	assert.False(t, controller.IsProtectedOperation(controller.RunCmd(), "create"))
+
+	for _, flag := range []string{"is-destroy", "auto-apply", "allow-empty-apply"} {
+		cmd := controller.RunCmd()
+		assert.Nil(t, cmd.Flags().Set(flag, "true"))
+		assert.True(t, controller.IsProtectedOperation(cmd, "create"), flag)
+	}
+
+	cmd := controller.RunCmd()
+	assert.Nil(t, cmd.Flags().Set("auto-apply", "false"))
+	assert.False(t, controller.IsProtectedOperation(cmd, "create"))
+	assert.True(t, controller.IsProtectedOperation(controller.RunCmd(), "apply"))
+}
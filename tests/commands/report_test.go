@@ -0,0 +1,59 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRunReport(t *testing.T) {
+	t.Setenv("TFE_ADDRESS", "https://tfe.example.com")
+
+	plan, err := aid.ParsePlanJSON([]byte(planJSONFixture))
+	assert.Nil(t, err)
+
+	run := &tfe.Run{
+		ID:           "run-123",
+		Status:       tfe.RunPlanned,
+		Workspace:    &tfe.Workspace{ID: "ws-123", Name: "app-prod"},
+		CostEstimate: &tfe.CostEstimate{Status: tfe.CostEstimateFinished, DeltaMonthlyCost: "15.50"},
+	}
+	policyChecks := []*tfe.PolicyCheck{{ID: "polchk-123", Status: tfe.PolicySoftFailed, Result: &tfe.PolicyResult{Passed: 3, SoftFailed: 1}}}
+	report := aid.NewRunReport("my-org", run, &tfe.Plan{ResourceAdditions: 1, ResourceDestructions: 2}, aid.GetPlanSummary(plan), policyChecks)
+
+	assert.Equal(t, "https://tfe.example.com/app/my-org/workspaces/app-prod/runs/run-123", report.Run.URL)
+
+	for _, format := range aid.RunReportFormats {
+		text, err := aid.GetRunReportTemplate(format, "")
+		assert.Nil(t, err)
+
+		out, err := aid.RenderRunReport(report, format, text)
+		assert.Nil(t, err)
+		assert.Contains(t, out, report.Run.URL)
+		assert.Contains(t, out, "+1 ~0 -2")
+		assert.Contains(t, out, "module.db.aws_db_instance.main")
+		assert.Contains(t, out, "$15.50")
+		assert.Contains(t, out, "soft_failed")
+	}
+
+	out, err := aid.RenderRunReport(report, "markdown", "{{ .Run.ID }} {{ .Workspace.Name }}")
+	assert.Nil(t, err)
+	assert.Equal(t, "run-123 app-prod", out)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/controller"
+	"github.com/awslabs/tecli/cobra/model"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSummary(t *testing.T) {
+	plan := &tfe.Plan{ResourceAdditions: 3, ResourceChanges: 1, ResourceDestructions: 0}
+	assert.Equal(t, "+3 ~1 -0", aid.FormatPlanSummary(plan))
+	assert.True(t, aid.PlanHasChanges(plan))
+
+	assert.Equal(t, "+0 ~0 -0", aid.FormatPlanSummary(&tfe.Plan{}))
+	assert.False(t, aid.PlanHasChanges(&tfe.Plan{}))
+}
+
+func TestRunPlanStatus(t *testing.T) {
+	assert.False(t, aid.IsRunPlanSettled(tfe.RunPlanning))
+	assert.False(t, aid.IsRunPlanSettled(tfe.RunPlanQueued))
+	assert.True(t, aid.IsRunPlanSettled(tfe.RunPlanned))
+	assert.True(t, aid.IsRunPlanSettled(tfe.RunPlannedAndFinished))
+	assert.True(t, aid.IsRunFailed(tfe.RunErrored))
+	assert.False(t, aid.IsRunFailed(tfe.RunPlanned))
+}
+
+func TestMatchAddressPattern(t *testing.T) {
+	patterns := []string{"module.db.*", "aws_iam_*"}
+
+	pattern, ok := aid.MatchAddressPattern(patterns, "module.db.aws_db_instance.main[0]")
+	assert.True(t, ok)
+	assert.Equal(t, "module.db.*", pattern)
+
+	_, ok = aid.MatchAddressPattern(patterns, "aws_iam_role.ci")
+	assert.True(t, ok)
+
+	_, ok = aid.MatchAddressPattern(patterns, "module.app.aws_iam_role.ci")
+	assert.False(t, ok)
+}
+
+func TestCheckPlanPreconditions(t *testing.T) {
+	plan := &tfe.Plan{ResourceAdditions: 1, ResourceChanges: 1, ResourceDestructions: 1}
+	planJSON := &model.PlanJSON{ResourceChanges: []model.ResourceChange{
+		{Address: "aws_iam_role.ci", Change: model.Change{Actions: []string{"no-op"}}},
+		{Address: "aws_s3_bucket.logs", Change: model.Change{Actions: []string{"delete"}}},
+	}}
+
+	violations := aid.CheckPlanPreconditions(aid.ApplyPreconditions{MaxChanges: -1}, plan, planJSON)
+	assert.Empty(t, violations)
+
+	preconditions := aid.ApplyPreconditions{IfNoDestroy: true, MaxChanges: 2, DenyAddrs: []string{"aws_iam_*", "aws_s3_*"}}
+	violations = aid.CheckPlanPreconditions(preconditions, plan, planJSON)
+	assert.Len(t, violations, 3)
+	assert.Contains(t, violations[2], "aws_s3_bucket.logs")
+}
+
+func TestRunListOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetRunFlags(cmd)
+	assert.Nil(t, cmd.Flags().Set("status", "applied,errored"))
+	assert.Nil(t, cmd.Flags().Set("operation", "plan-only"))
+	assert.Nil(t, cmd.Flags().Set("source", "vcs"))
+	assert.Nil(t, cmd.Flags().Set("source", "cli"))
+	assert.Nil(t, cmd.Flags().Set("since", "24h"))
+
+	options, err := aid.GetRunListOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "applied,errored", options.Status)
+	assert.Equal(t, "plan_only", options.Operation)
+	assert.Equal(t, "tfe-configuration-version,terraform,terraform+cloud", options.Source)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	filter, err := aid.GetRunListFilter(cmd, now)
+	assert.Nil(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), filter.Since)
+	assert.Equal(t, 20, filter.Limit)
+
+	assert.True(t, filter.InRunListWindow(&tfe.Run{CreatedAt: now.Add(-time.Hour)}))
+	assert.False(t, filter.InRunListWindow(&tfe.Run{CreatedAt: now.Add(-48 * time.Hour)}))
+
+	assert.Nil(t, cmd.Flags().Set("source", "github"))
+	_, err = aid.GetRunListOptions(cmd)
+	assert.NotNil(t, err)
+}
+
+func TestSortRuns(t *testing.T) {
+	now := time.Now()
+	runs := []*tfe.Run{
+		{ID: "run-1", Status: tfe.RunErrored, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "run-2", Status: tfe.RunApplied, CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "run-3", Status: tfe.RunApplied, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	aid.SortRuns(runs, "created-at")
+	assert.Equal(t, []string{"run-3", "run-1", "run-2"}, []string{runs[0].ID, runs[1].ID, runs[2].ID})
+
+	aid.SortRuns(runs, "status")
+	assert.Equal(t, []string{"run-3", "run-2", "run-1"}, []string{runs[0].ID, runs[1].ID, runs[2].ID})
+}
+
+func TestRenderRunDashboard(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	rows := []aid.RunDashboardRow{
+		{Workspace: "app-prod", Run: &tfe.Run{ID: "run-2", Status: tfe.RunPlanned, CreatedAt: now.Add(-90 * time.Minute), Actions: &tfe.RunActions{IsConfirmable: true}}},
+		{Workspace: "app-dev", Run: &tfe.Run{ID: "run-1", Status: tfe.RunPending, CreatedAt: now.Add(-30 * time.Second), Message: "Queued manually"}},
+	}
+
+	out := aid.RenderRunDashboard(rows, now)
+	assert.Regexp(t, `app-dev\s+run-1\s+pending\s+30s\s+no\s+Queued manually`, out)
+	assert.Regexp(t, `app-prod\s+run-2\s+planned\s+1h\s+yes`, out)
+	assert.Less(t, strings.Index(out, "app-dev"), strings.Index(out, "app-prod"))
+
+	assert.Equal(t, "no active run\n", aid.RenderRunDashboard(nil, now))
+
+	assert.True(t, aid.IsRunActive(tfe.RunPolicySoftFailed))
+	assert.False(t, aid.IsRunActive(tfe.RunApplied))
+	assert.Equal(t, "3d", aid.FormatAge(72*time.Hour))
+
+	for _, status := range strings.Split(aid.GetRunActiveStatusFilter(), ",") {
+		assert.True(t, aid.IsRunActive(tfe.RunStatus(status)), status)
+	}
+	assert.NotContains(t, strings.Split(aid.GetRunActiveStatusFilter(), ","), string(tfe.RunApplied))
+}
+
+func TestDiagnoseRun(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	run := &tfe.Run{ID: "run-2", Status: tfe.RunPending, CreatedAt: now.Add(-5 * time.Minute)}
+	current := &tfe.Run{ID: "run-1", Status: tfe.RunApplying, CreatedAt: now.Add(-14 * time.Minute), Actions: &tfe.RunActions{IsCancelable: true}}
+	workspace := &tfe.Workspace{ID: "ws-1", Name: "app-prod", Locked: true, LockedBy: &tfe.LockedByChoice{Run: &tfe.Run{ID: "run-1"}}}
+
+	diagnosis := aid.DiagnoseRun(aid.RunDiagnosisInput{Run: run, Workspace: workspace, LockingRun: current, CurrentRun: current}, now)
+	assert.Equal(t, []string{"the workspace is locked by run-1 (applying, started 14m ago)"}, diagnosis.Reasons)
+	assert.Equal(t, []string{"tecli run cancel --id run-1"}, diagnosis.Commands)
+
+	workspace = &tfe.Workspace{ID: "ws-1", Name: "app-prod", Locked: true, LockedBy: &tfe.LockedByChoice{User: &tfe.User{Username: "jdoe"}}}
+	diagnosis = aid.DiagnoseRun(aid.RunDiagnosisInput{Run: run, Workspace: workspace}, now)
+	assert.Equal(t, []string{"the workspace is locked by user jdoe"}, diagnosis.Reasons)
+	assert.Contains(t, diagnosis.Commands, "tecli workspace unlock --id ws-1")
+
+	diagnosis = aid.DiagnoseRun(aid.RunDiagnosisInput{Run: run, Workspace: &tfe.Workspace{Name: "app-dev"}}, now)
+	assert.Contains(t, diagnosis.Reasons[0], "nothing on the workspace blocks it")
+
+	planned := &tfe.Run{ID: "run-3", Status: tfe.RunPlanned, Actions: &tfe.RunActions{IsConfirmable: true, IsDiscardable: true}}
+	diagnosis = aid.DiagnoseRun(aid.RunDiagnosisInput{Run: planned, Workspace: &tfe.Workspace{Name: "app-dev"}}, now)
+	assert.Equal(t, []string{"tecli run apply --id run-3", "tecli run discard --id run-3"}, diagnosis.Commands)
+}
+
+func TestRenderRunActionResults(t *testing.T) {
+	results := []aid.RunActionResult{
+		{RunID: "run-2", Status: "pending", Action: "cancel"},
+		{RunID: "run-1", Status: "planning", Action: "cancel", Err: errors.New("conflict\nrun is already finishing")},
+	}
+
+	out := aid.RenderRunActionResults(results)
+	assert.Regexp(t, `run-2\s+pending\s+cancel\s+ok`, out)
+	assert.Regexp(t, `run-1\s+planning\s+cancel\s+failed\s+conflict run is already finishing\n`, out)
+	assert.Less(t, strings.Index(out, "run-2"), strings.Index(out, "run-1"))
+
+	run := &tfe.Run{Actions: &tfe.RunActions{IsCancelable: true}}
+	assert.True(t, aid.IsRunActionable(run, "cancel"))
+	assert.False(t, aid.IsRunActionable(run, "discard"))
+	assert.False(t, aid.IsRunActionable(&tfe.Run{}, "cancel"))
+}
+
+func TestParseRunVariables(t *testing.T) {
+	variables, err := aid.ParseRunVariables([]string{"region=us-east-1", "count=3", "enabled=true", `tags={a="b"}`, "empty="})
+	assert.NoError(t, err)
+	values := make(map[string]string)
+	for _, v := range variables {
+		values[v.Key] = v.Value
+	}
+	assert.Equal(t, map[string]string{"region": `"us-east-1"`, "count": "3", "enabled": "true", "tags": `{a="b"}`, "empty": `""`}, values)
+
+	tests := []struct {
+		value string
+		hcl   string
+	}{
+		{"0", "0"},
+		{"-2.5", "-2.5"},
+		{"1e3", "1e3"},
+		{"1e999", "1e999"},
+		{"inf", `"inf"`},
+		{"NaN", `"NaN"`},
+		{"0x1p-2", `"0x1p-2"`},
+		{"1_000", `"1_000"`},
+		{"+1", `"+1"`},
+		{".5", `".5"`},
+		{"007", `"007"`},
+	}
+
+	for _, test := range tests {
+		variables, err := aid.ParseRunVariables([]string{"v=" + test.value})
+		assert.NoError(t, err)
+		assert.Equal(t, test.hcl, variables[0].Value, test.value)
+	}
+
+	_, err = aid.ParseRunVariables([]string{"region"})
+	assert.Error(t, err)
+
+	_, err = aid.ParseRunVariables([]string{"a=1", "a=2"})
+	assert.Error(t, err)
+}
+
+func TestCheckRunCreateOptions(t *testing.T) {
+	yes, no, version := true, false, "1.9.0"
+
+	assert.NoError(t, aid.CheckRunCreateOptions(tfe.RunCreateOptions{PlanOnly: &yes, TerraformVersion: &version}))
+	assert.NoError(t, aid.CheckRunCreateOptions(tfe.RunCreateOptions{Refresh: &no, ReplaceAddrs: []string{"aws_s3_bucket.logs"}}))
+
+	assert.Error(t, aid.CheckRunCreateOptions(tfe.RunCreateOptions{RefreshOnly: &yes, Refresh: &no}))
+	assert.Error(t, aid.CheckRunCreateOptions(tfe.RunCreateOptions{IsDestroy: &yes, ReplaceAddrs: []string{"aws_s3_bucket.logs"}}))
+	assert.Error(t, aid.CheckRunCreateOptions(tfe.RunCreateOptions{PlanOnly: &yes, AutoApply: &yes}))
+	assert.Error(t, aid.CheckRunCreateOptions(tfe.RunCreateOptions{TerraformVersion: &version}))
+}
+
+func TestRunPsPages(t *testing.T) {
+	fakeTFE(t, map[string]string{
+		"GET /api/v2/organizations/acme/workspaces":       `{"data":[{"id":"ws-1","type":"workspaces","attributes":{"name":"network"}}]}`,
+		"GET /api/v2/workspaces/ws-1/runs?page[number]=1": `{"data":[{"id":"run-1","type":"runs","attributes":{"status":"planning","message":"first"}}],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2}}}`,
+		"GET /api/v2/workspaces/ws-1/runs?page[number]=2": `{"data":[{"id":"run-2","type":"runs","attributes":{"status":"pending","message":"second"}}],"meta":{"pagination":{"current-page":2,"total-pages":2}}}`,
+	})
+
+	// the active runs past the first page are listed too
+	out, err := executeCommandStdout(controller.RunCmd(), []string{"run", "ps"})
+	assert.NoError(t, err)
+	assert.Contains(t, out, "run-1")
+	assert.Contains(t, out, "run-2")
+}
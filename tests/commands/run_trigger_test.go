@@ -0,0 +1,101 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/controller"
+	"github.com/awslabs/tecli/cobra/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRunTriggerGraph(edges ...[2]string) model.RunTriggerGraph {
+	graph := aid.NewRunTriggerGraph()
+	for _, edge := range edges {
+		aid.AddRunTrigger(&graph, edge[0], edge[1])
+	}
+
+	return graph
+}
+
+func TestRunTriggerGraph(t *testing.T) {
+	graph := newRunTriggerGraph([2]string{"network", "app-prod"}, [2]string{"network", "app-dev"}, [2]string{"app-prod", "monitoring"}, [2]string{"network", "app-dev"})
+
+	assert.Equal(t, []string{"app-dev", "app-prod", "monitoring", "network"}, graph.Workspaces)
+	assert.Equal(t, []string{"app-dev", "app-prod"}, graph.Triggers["network"])
+	assert.Empty(t, aid.FindRunTriggerCycles(graph))
+
+	assert.Equal(t, []string{"app-dev", "app-prod", "monitoring"}, aid.GetTriggeredWorkspaces(graph, "network"))
+	assert.Equal(t, []string{"monitoring"}, aid.GetTriggeredWorkspaces(graph, "app-prod"))
+	assert.Empty(t, aid.GetTriggeredWorkspaces(graph, "app-dev"))
+
+	downstream := aid.GetDownstreamRunTriggerGraph(graph, "app-prod")
+	assert.Equal(t, []string{"app-prod", "monitoring"}, downstream.Workspaces)
+
+	out, err := aid.RenderRunTriggerGraph(graph, "tree")
+	assert.Nil(t, err)
+	assert.Equal(t, "network\n├── app-dev\n└── app-prod\n    └── monitoring\n", out)
+}
+
+func TestRunTriggerGraphCycles(t *testing.T) {
+	graph := newRunTriggerGraph([2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "b"}, [2]string{"d", "d"})
+
+	assert.Equal(t, [][]string{{"b", "c", "b"}, {"d", "d"}}, aid.FindRunTriggerCycles(graph))
+	assert.Equal(t, []string{"b", "c"}, aid.GetTriggeredWorkspaces(graph, "a"))
+
+	out, err := aid.RenderRunTriggerGraph(graph, "tree")
+	assert.Nil(t, err)
+	assert.Equal(t, "a\n└── b\n    └── c\n        └── b (cycle)\nd\n└── d (cycle)\n", out)
+
+	out, err = aid.RenderRunTriggerGraph(graph, "dot")
+	assert.Nil(t, err)
+	assert.Contains(t, out, `"c" -> "b" [color=red, label="cycle"];`)
+	assert.Contains(t, out, `"a" -> "b";`)
+
+	out, err = aid.RenderRunTriggerGraph(graph, "mermaid")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "w2 -->|cycle| w1")
+	assert.Contains(t, out, "linkStyle 2,3 stroke:red")
+}
+
+func TestWorkspaceGraphStdout(t *testing.T) {
+	fakeTFE(t, map[string]string{
+		"GET /api/v2/organizations/acme/workspaces":         `{"data":[{"id":"ws-1","type":"workspaces","attributes":{"name":"network"}},{"id":"ws-2","type":"workspaces","attributes":{"name":"app"}}]}`,
+		"GET /api/v2/organizations/acme/workspaces/network": `{"data":{"id":"ws-1","type":"workspaces","attributes":{"name":"network"}}}`,
+		"GET /api/v2/organizations/acme/workspaces/app":     `{"data":{"id":"ws-2","type":"workspaces","attributes":{"name":"app"}}}`,
+		"GET /api/v2/workspaces/ws-1/run-triggers":          `{"data":[]}`,
+		"GET /api/v2/workspaces/ws-2/run-triggers":          `{"data":[{"id":"rt-1","type":"run-triggers","attributes":{"sourceable-name":"network","workspace-name":"app"}}]}`,
+	})
+
+	// the documents are printed alone, so they can be piped to dot or embedded as they are
+	for _, args := range [][]string{
+		{"--format", "dot"},
+		{"--format", "dot", "--name", "network"},
+		{"--format", "dot", "--name", "app"},
+	} {
+		out, err := executeCommandStdout(controller.WorkspaceCmd(), append([]string{"workspace", "graph"}, args...))
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(out, "digraph"), out)
+	}
+
+	out, err := executeCommandStdout(controller.WorkspaceCmd(), []string{"workspace", "graph", "--format", "mermaid"})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "graph"), out)
+	assert.Contains(t, out, "network")
+}
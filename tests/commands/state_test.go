@@ -0,0 +1,191 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/controller"
+	"github.com/awslabs/tecli/cobra/model"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+)
+
+const stateFixture = `{"version":4,"terraform_version":"1.9.0","serial":12,"lineage":"3f1c","resources":[` +
+	`{"mode":"managed","type":"aws_s3_bucket","name":"logs","instances":[{}]},` +
+	`{"mode":"managed","type":"aws_instance","name":"web","instances":[{"index_key":0},{"index_key":1}]},` +
+	`{"mode":"data","type":"aws_ami","name":"ubuntu","instances":[{}]}]}`
+
+func TestParseStateFile(t *testing.T) {
+	state, err := aid.ParseStateFile([]byte(stateFixture))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), state.Serial)
+	assert.Equal(t, "3f1c", state.Lineage)
+	assert.Equal(t, 3, aid.CountStateInstances(state))
+
+	_, err = aid.ParseStateFile([]byte(`{"serial":1}`))
+	assert.Error(t, err)
+}
+
+func TestCheckStatePush(t *testing.T) {
+	current := &model.StateFile{Version: 4, Serial: 12, Lineage: "3f1c"}
+
+	assert.Empty(t, aid.CheckStatePush(&model.StateFile{Serial: 13, Lineage: "3f1c"}, current, false))
+	assert.Empty(t, aid.CheckStatePush(&model.StateFile{Serial: 1, Lineage: "other"}, nil, false))
+	assert.Len(t, aid.CheckStatePush(&model.StateFile{Serial: 11, Lineage: "3f1c"}, current, false), 1)
+	assert.Len(t, aid.CheckStatePush(&model.StateFile{Serial: 12, Lineage: "3f1c"}, current, false), 1)
+	assert.Len(t, aid.CheckStatePush(&model.StateFile{Serial: 5, Lineage: "other"}, current, false), 2)
+	assert.Empty(t, aid.CheckStatePush(&model.StateFile{Serial: 5, Lineage: "other"}, current, true))
+}
+
+func TestStateVersionCreateOptions(t *testing.T) {
+	data := []byte(stateFixture)
+	state, err := aid.ParseStateFile(data)
+	assert.NoError(t, err)
+
+	options := aid.GetStateVersionCreateOptions(state, data, false)
+	assert.Equal(t, fmt.Sprintf("%x", md5.Sum(data)), *options.MD5)
+	assert.Equal(t, int64(12), *options.Serial)
+	assert.Equal(t, "3f1c", *options.Lineage)
+	assert.Nil(t, options.Force)
+
+	decoded, err := base64.StdEncoding.DecodeString(*options.State)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	assert.True(t, *aid.GetStateVersionCreateOptions(state, data, true).Force)
+}
+
+func TestDecodeStateDownload(t *testing.T) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	w.Write([]byte(`{"version":4}`))
+	w.Close()
+
+	data, err := aid.DecodeStateDownload(compressed.Bytes(), true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"version\": 4\n}\n", string(data))
+
+	data, err = aid.DecodeStateDownload([]byte(`{"version":4}`), true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version":4}`, string(data))
+}
+
+func TestStateOutputs(t *testing.T) {
+	outputs := []*tfe.StateVersionOutput{
+		{Name: "vpc_id", Value: "vpc-123"},
+		{Name: "port", Value: float64(5432)},
+		{Name: "db_password", Sensitive: true},
+		{Name: "subnets", Value: []interface{}{"a", "b"}},
+	}
+
+	values, sensitive := aid.GetStateOutputValues(outputs)
+	assert.Equal(t, []string{"db_password"}, sensitive)
+	assert.Len(t, values, 3)
+
+	raw, err := aid.FormatRawOutput(outputs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "vpc-123", raw)
+
+	raw, err = aid.FormatRawOutput(outputs[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "5432", raw)
+
+	_, err = aid.FormatRawOutput(outputs[2])
+	assert.Error(t, err)
+
+	_, err = aid.FormatRawOutput(outputs[3])
+	assert.Error(t, err)
+}
+
+func TestExpandStateMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{"envs/terraform.tfstate", "envs/dev/terraform.tfstate", "envs/prod/eu/terraform.tfstate", "legacy.tfstate"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(`{}`), 0600))
+	}
+
+	manifest, err := aid.ParseStateMigrationManifest([]byte("states:\n  - file: legacy.tfstate\n    workspace: legacy\n  - dir: envs\n    workspace-prefix: app-\n"))
+	assert.NoError(t, err)
+
+	items, err := aid.ExpandStateMigration(manifest, dir)
+	assert.NoError(t, err)
+
+	var workspaces []string
+	for _, item := range items {
+		workspaces = append(workspaces, item.Workspace)
+	}
+	assert.Equal(t, []string{"legacy", "app-dev", "app-prod-eu", "app"}, workspaces)
+	assert.Equal(t, filepath.Join(dir, "legacy.tfstate"), items[0].File)
+
+	manifest, err = aid.ParseStateMigrationManifest([]byte("states:\n  - file: legacy.tfstate\n    workspace: app-dev\n  - dir: envs\n    workspace-prefix: app-\n"))
+	assert.NoError(t, err)
+	_, err = aid.ExpandStateMigration(manifest, dir)
+	assert.Error(t, err)
+
+	_, err = aid.ParseStateMigrationManifest([]byte("states:\n  - file: legacy.tfstate\n"))
+	assert.Error(t, err)
+
+	_, err = aid.ParseStateMigrationManifest([]byte("states:\n  - file: legacy.tfstate\n    workspaces: legacy\n"))
+	assert.Error(t, err)
+}
+
+func TestStateMigrationProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+
+	progress, err := aid.ReadStateMigrationProgress(path)
+	assert.NoError(t, err)
+	assert.Empty(t, progress.Entries)
+
+	item := model.StateMigrationItem{File: "dev.tfstate", Workspace: "app-dev"}
+	aid.SetStateMigrationProgress(progress, model.StateMigrationProgressEntry{File: item.File, Workspace: item.Workspace, MD5: "a", Status: aid.StateMigrationFailed})
+	assert.False(t, aid.IsStateMigrationDone(progress, item, "a"))
+
+	aid.SetStateMigrationProgress(progress, model.StateMigrationProgressEntry{File: item.File, Workspace: item.Workspace, MD5: "a", Status: aid.StateMigrationDone})
+	assert.NoError(t, aid.WriteStateMigrationProgress(path, progress))
+
+	progress, err = aid.ReadStateMigrationProgress(path)
+	assert.NoError(t, err)
+	assert.Len(t, progress.Entries, 1)
+	assert.True(t, aid.IsStateMigrationDone(progress, item, "a"))
+	assert.False(t, aid.IsStateMigrationDone(progress, item, "b"))
+}
+
+func TestStateStdout(t *testing.T) {
+	state := `{"version":4,"serial":3,"lineage":"abc","resources":[]}`
+	fakeTFE(t, map[string]string{
+		"GET /api/v2/state-versions/sv-1":         `{"data":{"id":"sv-1","type":"state-versions","attributes":{"serial":3,"hosted-state-download-url":"/state/sv-1"}}}`,
+		"GET /state/sv-1":                         state,
+		"GET /api/v2/state-versions/sv-1/outputs": `{"data":[{"id":"wsout-1","type":"state-version-outputs","attributes":{"name":"vpc_id","type":"string","value":"vpc-123"}}]}`,
+	})
+
+	// stdout holds the state or the value alone, so it can be redirected or captured
+	out, err := executeCommandStdout(controller.StateCmd(), []string{"state", "download", "--id", "sv-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, state, out)
+
+	out, err = executeCommandStdout(controller.StateCmd(), []string{"state", "outputs", "--id", "sv-1", "--raw", "vpc_id"})
+	assert.NoError(t, err)
+	assert.Equal(t, "vpc-123", out)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/model"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchWorkspaceNames(t *testing.T) {
+	names := []string{"app-dev", "app-prod", "network", "app"}
+
+	matched, err := aid.MatchWorkspaceNames(names, []string{"network", "app-*"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"app-dev", "app-prod", "network"}, matched)
+
+	_, err = aid.MatchWorkspaceNames(names, []string{"db-*"})
+	assert.ErrorIs(t, err, tfe.ErrResourceNotFound)
+
+	_, err = aid.MatchWorkspaceNames(names, []string{"app-["})
+	assert.Error(t, err)
+
+	assert.True(t, aid.IsWorkspacePattern("app-*"))
+	assert.False(t, aid.IsWorkspacePattern("app-dev"))
+}
+
+func TestTeamAccessAddOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetTeamAccessFlags(cmd)
+
+	_, err := aid.GetTeamAccessAddOptions(cmd)
+	assert.Error(t, err)
+
+	assert.Nil(t, cmd.Flags().Set("access", "write"))
+	assert.Nil(t, cmd.Flags().Set("runs", "plan"))
+	_, err = aid.GetTeamAccessAddOptions(cmd)
+	assert.Error(t, err)
+
+	assert.Nil(t, cmd.Flags().Set("access", "custom"))
+	assert.Nil(t, cmd.Flags().Set("workspace-locking", "true"))
+	options, err := aid.GetTeamAccessAddOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, tfe.AccessCustom, *options.Access)
+	assert.Equal(t, tfe.RunsPermissionPlan, *options.Runs)
+	assert.True(t, *options.WorkspaceLocking)
+	assert.Nil(t, options.Variables)
+
+	assert.Nil(t, cmd.Flags().Set("state-versions", "all"))
+	_, err = aid.GetTeamAccessAddOptions(cmd)
+	assert.Error(t, err)
+}
+
+func TestRenderTeamAccessMatrix(t *testing.T) {
+	entries := []model.TeamAccessEntry{
+		{Workspace: "app-dev", Team: "platform", Access: "write"},
+		{Workspace: "app-prod", Team: "platform", Access: "read"},
+		{Workspace: "app-prod", Team: "sre", Access: "custom", Runs: "apply", Variables: "read", StateVersions: "read", SentinelMocks: "none", WorkspaceLocking: true},
+	}
+
+	matrix := aid.NewTeamAccessMatrix(entries, []string{"sre", "platform", "auditors"}, []string{"app-prod", "app-dev"})
+	out, err := aid.RenderTeamAccessMatrix(matrix, "csv")
+	assert.Nil(t, err)
+	assert.Equal(t, `workspace,auditors,platform,sre
+app-dev,,write,
+app-prod,,read,custom(runs=apply variables=read state-versions=read sentinel-mocks=none workspace-locking=true run-tasks=false)
+`, out)
+
+	out, err = aid.RenderTeamAccessMatrix(matrix, "table")
+	assert.Nil(t, err)
+	assert.Regexp(t, `app-dev\s+-\s+write\s+-`, out)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamCreateOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetTeamFlags(cmd)
+	assert.Nil(t, cmd.Flags().Set("name", "platform"))
+	assert.Nil(t, cmd.Flags().Set("visibility", "organization"))
+	assert.Nil(t, cmd.Flags().Set("manage-workspaces", "true"))
+	assert.Nil(t, cmd.Flags().Set("read-projects", "false"))
+
+	options, err := aid.GetTeamCreateOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "platform", *options.Name)
+	assert.Equal(t, "organization", *options.Visibility)
+	assert.Nil(t, options.SSOTeamID)
+	assert.True(t, *options.OrganizationAccess.ManageWorkspaces)
+	assert.False(t, *options.OrganizationAccess.ReadProjects)
+	assert.Nil(t, options.OrganizationAccess.ManagePolicies)
+
+	assert.Nil(t, cmd.Flags().Set("visibility", "public"))
+	_, err = aid.GetTeamCreateOptions(cmd)
+	assert.Error(t, err)
+}
+
+func TestTeamUpdateOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	aid.SetTeamFlags(cmd)
+	assert.Nil(t, cmd.Flags().Set("new-name", "platform-eng"))
+
+	options, err := aid.GetTeamUpdateOptions(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, "platform-eng", *options.Name)
+	assert.Nil(t, options.OrganizationAccess)
+	assert.Nil(t, options.Visibility)
+
+	_, err = aid.GetTeamUsernames(cmd)
+	assert.Error(t, err)
+
+	assert.Nil(t, cmd.Flags().Set("username", "alice,bob"))
+	usernames, err := aid.GetTeamUsernames(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, usernames)
+}
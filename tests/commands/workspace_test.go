@@ -0,0 +1,87 @@
+/*
+Copyright © 2020 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/awslabs/tecli/cobra/aid"
+	"github.com/awslabs/tecli/cobra/controller"
+	"github.com/stretchr/testify/assert"
+)
+
+func workspaceOutputsFixture() map[string]interface{} {
+	return map[string]interface{}{
+		"vpc_id":  "vpc-123",
+		"port":    float64(5432),
+		"subnets": []interface{}{"subnet-a", "subnet-b"},
+		"tags":    map[string]interface{}{"cost-center": "${x}", "owner": "it's me"},
+	}
+}
+
+func TestFlattenOutputs(t *testing.T) {
+	vars, err := aid.FlattenOutputs(workspaceOutputsFixture(), "tf")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"TF_VPC_ID":           "vpc-123",
+		"TF_PORT":             "5432",
+		"TF_SUBNETS_0":        "subnet-a",
+		"TF_SUBNETS_1":        "subnet-b",
+		"TF_TAGS_COST_CENTER": "${x}",
+		"TF_TAGS_OWNER":       "it's me",
+	}, vars)
+
+	_, err = aid.FlattenOutputs(map[string]interface{}{"a_b": "1", "a": map[string]interface{}{"b": "2"}}, "")
+	assert.Error(t, err)
+}
+
+func TestRenderWorkspaceOutputs(t *testing.T) {
+	out, err := aid.RenderWorkspaceOutputs(workspaceOutputsFixture(), "env", "")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "export TAGS_OWNER='it'\\''s me'\n")
+	assert.Contains(t, out, "export PORT='5432'\n")
+
+	out, err = aid.RenderWorkspaceOutputs(map[string]interface{}{"password": "a\"b\nc$d"}, "dotenv", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "PASSWORD=\"a\\\"b\\nc\\$d\"\n", out)
+
+	out, err = aid.RenderWorkspaceOutputs(workspaceOutputsFixture(), "tfvars", "")
+	assert.NoError(t, err)
+	assert.Equal(t, `port = 5432
+subnets = ["subnet-a", "subnet-b"]
+tags = {
+  "cost-center" = "$${x}"
+  "owner" = "it's me"
+}
+vpc_id = "vpc-123"
+`, out)
+}
+
+func TestWorkspaceOutputsStdout(t *testing.T) {
+	fakeTFE(t, map[string]string{
+		"GET /api/v2/organizations/acme/workspaces/app":             `{"data":{"id":"ws-1","type":"workspaces","attributes":{"name":"app"}}}`,
+		"GET /api/v2/workspaces/ws-1/current-state-version-outputs": `{"data":[{"id":"wsout-1","type":"state-version-outputs","attributes":{"name":"vpc_id","type":"string","value":"vpc-123"}}]}`,
+	})
+
+	// stdout holds the outputs alone, so it can be evaluated or parsed
+	out, err := executeCommandStdout(controller.WorkspaceCmd(), []string{"workspace", "outputs", "--name", "app", "--format", "env", "--prefix", "tf"})
+	assert.NoError(t, err)
+	assert.Equal(t, "export TF_VPC_ID='vpc-123'\n", out)
+
+	out, err = executeCommandStdout(controller.WorkspaceCmd(), []string{"workspace", "outputs", "--name", "app", "--format", "json"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"vpc_id":"vpc-123"}`, out)
+}